@@ -0,0 +1,12 @@
+# Take 15% off every Jaguar priced in USD. Preview the new prices with
+# dry_run = true, then run terraform apply -invoke=action.inventory_reprice.sale
+action "inventory_reprice" "sale" {
+  config {
+    filter = {
+      name_regex = "Jaguar"
+    }
+    currency = "USD"
+    percent  = -15
+    rounding = "down"
+  }
+}
@@ -0,0 +1,18 @@
+# Export the priced cars, most expensive first
+data "inventory_export" "example" {
+  format          = "csv"
+  columns         = ["id", "name"]
+  price_columns   = true
+  sort_by         = "amount"
+  sort_descending = true
+
+  filter = {
+    tag_regex = "^[A-Z]{3}:"
+  }
+}
+
+# Write a catalog snapshot next to the configuration
+resource "local_file" "catalog" {
+  filename = "${path.module}/catalog.csv"
+  content  = data.inventory_export.example.content
+}
@@ -2,3 +2,15 @@
 data "inventory_item" "example" {
   id = "1000"
 }
+
+# Use an item only if it exists
+data "inventory_item" "optional" {
+  id            = "1001"
+  allow_missing = true
+}
+
+resource "inventory_item" "accessory" {
+  count = data.inventory_item.optional.exists ? 1 : 0
+
+  name = "${data.inventory_item.optional.name} floor mats"
+}
@@ -0,0 +1,14 @@
+# Wait for another pipeline to create and price an item
+data "inventory_item_wait" "example" {
+  name      = "2022 Mustang Shelby GT500"
+  tag_regex = "^USD:"
+
+  interval     = "2s"
+  max_interval = "30s"
+  timeout      = "10m"
+}
+
+resource "inventory_item" "accessory" {
+  name = "${data.inventory_item_wait.example.name} floor mats"
+  tag  = "for item ${data.inventory_item_wait.example.id}"
+}
@@ -0,0 +1,13 @@
+# Fetch many items in a single data source
+data "inventory_items_by_ids" "example" {
+  ids         = [1000, 1001, 1002]
+  concurrency = 4
+}
+
+output "names" {
+  value = { for id, item in data.inventory_items_by_ids.example.items : id => item.name }
+}
+
+output "missing" {
+  value = data.inventory_items_by_ids.example.missing_ids
+}
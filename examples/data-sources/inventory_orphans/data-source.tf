@@ -0,0 +1,6 @@
+# List items stamped with this configuration's owner ID that are no longer
+# managed by it
+data "inventory_orphans" "example" {
+  owner_id    = "team-a/production"
+  exclude_ids = [inventory_item.example.id]
+}
@@ -0,0 +1,11 @@
+# Find the three most expensive Shelbys priced over EUR 100
+data "inventory_search" "example" {
+  expression      = "currency == \"EUR\" && amount > 100 && name.contains(\"Shelby\")"
+  sort_by         = "amount"
+  sort_descending = true
+  limit           = 3
+}
+
+output "top_shelbys" {
+  value = [for item in data.inventory_search.example.items : "${item.name}: ${item.amount}"]
+}
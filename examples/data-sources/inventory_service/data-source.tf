@@ -0,0 +1,14 @@
+# Warn when the inventory service is slow or unhealthy
+data "inventory_service" "example" {}
+
+check "inventory_service_health" {
+  assert {
+    condition     = data.inventory_service.example.healthy
+    error_message = "The inventory service at ${data.inventory_service.example.endpoint} is unhealthy: ${coalesce(data.inventory_service.example.error, "status ${data.inventory_service.example.status_code}")}."
+  }
+
+  assert {
+    condition     = coalesce(data.inventory_service.example.latency_ms, 0) < 500
+    error_message = "The inventory service took ${data.inventory_service.example.latency_ms}ms to respond."
+  }
+}
@@ -0,0 +1,21 @@
+# Aggregate the prices of the production cars
+data "inventory_stats" "example" {
+  filter = {
+    name_regex = "Jaguar|Shelby"
+    labels = {
+      env = "prod"
+    }
+  }
+}
+
+output "average_usd" {
+  value = data.inventory_stats.example.prices["USD"].average
+}
+
+# Fail the run when the stock is worth more than expected
+check "stock_value" {
+  assert {
+    condition     = data.inventory_stats.example.prices["USD"].total < 1000000
+    error_message = "The production stock is worth more than USD 1,000,000."
+  }
+}
@@ -0,0 +1,6 @@
+# Create a scratch item for the duration of the run. It is deleted when the
+# run finishes and never stored in state.
+ephemeral "inventory_item_lease" "scratch" {
+  name = "Integration Test Soda"
+  tag  = "USD:2.99"
+}
@@ -0,0 +1,7 @@
+# Convert a price to euros, for example EUR:73,066.40 for USD:79,420
+output "price_eur" {
+  value = provider::inventory::convert_price(data.inventory_item.example.tag, "EUR", {
+    base  = "USD"
+    rates = { EUR = 0.92, GBP = 0.79 }
+  })
+}
@@ -0,0 +1,14 @@
+# A 10% discount on an item, for example USD:71,478 for USD:79,420
+locals {
+  price = provider::inventory::parse_tag(data.inventory_item.example.tag)
+}
+
+resource "inventory_item" "discounted" {
+  name = "${data.inventory_item.example.name} (discounted)"
+  tag  = provider::inventory::format_tag(local.price.currency, local.price.amount * 0.9)
+}
+
+# Format with German separators, giving EUR:1.234,50
+output "price_de" {
+  value = provider::inventory::format_tag("EUR", 1234.5, "de")
+}
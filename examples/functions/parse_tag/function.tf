@@ -0,0 +1,9 @@
+# The price of an item as a number, for example 79420 for USD:79,420
+output "price" {
+  value = provider::inventory::parse_tag(data.inventory_item.example.tag).amount
+}
+
+# Tags written with other separators can be parsed with a locale
+output "price_de" {
+  value = provider::inventory::parse_tag("EUR:1.234,50", "de")
+}
@@ -0,0 +1,9 @@
+# Round to whole cents, giving USD:3.00
+output "rounded" {
+  value = provider::inventory::round_price("USD:2.999")
+}
+
+# Yen have no minor units, giving JPY:1,000
+output "rounded_down" {
+  value = provider::inventory::round_price("JPY:1,000.9", "down")
+}
@@ -0,0 +1,10 @@
+# A 15% discount on an item, for example USD:67,507 for USD:79,420
+resource "inventory_item" "discounted" {
+  name = "${data.inventory_item.example.name} (discounted)"
+  tag  = provider::inventory::scale_price(data.inventory_item.example.tag, 0.85)
+}
+
+# Round fractions of a cent up, giving USD:0.34
+output "third" {
+  value = provider::inventory::scale_price("USD:1", 1 / 3, "up")
+}
@@ -0,0 +1,13 @@
+# The total price of two items in the same currency, for example USD:80,000
+output "total" {
+  value = provider::inventory::sum_prices([inventory_item.car.tag, inventory_item.trailer.tag])
+}
+
+# The total price of items in different currencies, in the currency of the
+# first item
+output "total_usd" {
+  value = provider::inventory::sum_prices(["USD:79,420", "EUR:1,200"], {
+    base  = "USD"
+    rates = { EUR = 0.92 }
+  })
+}
@@ -0,0 +1,9 @@
+# List the items tagged USD:79,420, for example with
+# terraform query -generate-config-out=generated.tf
+list "inventory_item" "example" {
+  provider = inventory
+
+  config {
+    tag = "USD:79,420"
+  }
+}
@@ -10,6 +10,20 @@ terraform {
 provider "inventory" {
   host = "127.0.0.1"
   port = "8080"
+
+  # Labels merged into every inventory item managed by this provider
+  default_labels = {
+    owner = "platform-team"
+  }
+
+  # Convert item prices to US dollars for base_amount
+  exchange_rates {
+    base_currency = "USD"
+    rates = {
+      EUR = 0.92
+      GBP = 0.79
+    }
+  }
 }
 
 # Read in a existing inventory item
@@ -21,4 +35,8 @@ data "inventory_item" "example" {
 resource "inventory_item" "example" {
   name = "car"
   tag  = "mustang"
+
+  labels = {
+    environment = "production"
+  }
 }
@@ -0,0 +1,7 @@
+import {
+  to = inventory_item.example
+  identity = {
+    id   = 1000
+    name = "example"
+  }
+}
@@ -1 +1,6 @@
 terraform import inventory_item.example 1000
+
+# Items can also be imported by name or by tag. The import fails, listing the
+# candidates, when more than one item matches.
+terraform import inventory_item.example 'name=1953 Jaguar C-Type'
+terraform import inventory_item.example 'tag=USD:79,420'
@@ -2,4 +2,8 @@
 resource "inventory_item" "example" {
   name = "car"
   tag  = "mustang"
+
+  labels = {
+    environment = "production"
+  }
 }
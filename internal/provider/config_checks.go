@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Configuration values may still be unknown when Terraform validates the
+// configuration, for example when they refer to a resource that is not created
+// yet, and validation has to skip them. The checks below are therefore called
+// both when validating the configuration and again when it is used, once every
+// value is known. Null and unknown values always pass.
+
+// compileRegexAttribute compiles the regular expression of the attribute at
+// the given path. It returns nil for null and unknown values.
+func compileRegexAttribute(value types.String, attrPath path.Path) (*regexp.Regexp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	regex, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Regular Expression",
+			err.Error(),
+		)
+		return nil, diags
+	}
+	return regex, diags
+}
+
+// checkMinimumAttribute checks that the integer attribute at the given path,
+// described by name, is at least minimum.
+func checkMinimumAttribute(value types.Int64, minimum int64, attrPath path.Path, summary string, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() || value.ValueInt64() >= minimum {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		attrPath,
+		summary,
+		fmt.Sprintf("The %s must be at least %d, got %d.", name, minimum, value.ValueInt64()),
+	)
+	return diags
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Private state keys used by conflict_detection.
+const (
+	// lastAppliedPrivateKey records the item as it was last written by
+	// Terraform. Refreshes warn about changes made since then.
+	lastAppliedPrivateKey = "last_applied"
+	// lastObservedPrivateKey records the item as it was last written or
+	// refreshed by Terraform, which is what the plan was based on. Updates
+	// fail when the item has changed since then.
+	lastObservedPrivateKey = "last_observed"
+)
+
+// lastAppliedItem is the item as it was recorded in private state. The tag is
+// the decrypted stored tag, including any reserved segments.
+type lastAppliedItem struct {
+	Name string `json:"name"`
+	Tag  string `json:"tag"`
+}
+
+// privateStateGetter is implemented by the private state of requests.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is implemented by the private state of responses.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getLastApplied returns the item recorded in private state under key, or nil
+// when nothing has been recorded, such as for imported items.
+func getLastApplied(ctx context.Context, private privateStateGetter, key string) (*lastAppliedItem, diag.Diagnostics) {
+	b, diags := private.GetKey(ctx, key)
+	if diags.HasError() || len(b) == 0 {
+		return nil, diags
+	}
+
+	var lastApplied lastAppliedItem
+	if err := json.Unmarshal(b, &lastApplied); err != nil {
+		diags.AddError(
+			"Invalid Private State for Item",
+			"The item recorded in private state could not be read: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	return &lastApplied, diags
+}
+
+// setLastApplied records the item in private state under each of the keys.
+func setLastApplied(ctx context.Context, private privateStateSetter, lastApplied lastAppliedItem, keys ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	b, err := json.Marshal(lastApplied)
+	if err != nil {
+		diags.AddError("Unable to Record Item in Private State", err.Error())
+		return diags
+	}
+
+	for _, key := range keys {
+		diags.Append(private.SetKey(ctx, key, b)...)
+	}
+	return diags
+}
+
+// itemDrift describes each field that differs between the expected and the
+// remote item, one line per field.
+func itemDrift(expected, remote lastAppliedItem) []string {
+	var drift []string
+
+	if expected.Name != remote.Name {
+		drift = append(drift, fmt.Sprintf("name: expected %q, found %q", expected.Name, remote.Name))
+	}
+
+	expectedTag := parseItemTag(expected.Tag)
+	remoteTag := parseItemTag(remote.Tag)
+
+	if expectedTag.Tag != remoteTag.Tag {
+		drift = append(drift, fmt.Sprintf("tag: expected %q, found %q", expectedTag.Tag, remoteTag.Tag))
+	}
+
+	keys := map[string]bool{}
+	for k := range expectedTag.Labels {
+		keys[k] = true
+	}
+	for k := range remoteTag.Labels {
+		keys[k] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, k := range sortedKeys {
+		expectedValue, expectedOK := expectedTag.Labels[k]
+		remoteValue, remoteOK := remoteTag.Labels[k]
+		if expectedOK == remoteOK && expectedValue == remoteValue {
+			continue
+		}
+		drift = append(drift, fmt.Sprintf("labels.%s: expected %s, found %s", k, describeLabel(expectedValue, expectedOK), describeLabel(remoteValue, remoteOK)))
+	}
+
+	if expectedTag.Owner != remoteTag.Owner {
+		drift = append(drift, fmt.Sprintf("owner_id: expected %q, found %q", expectedTag.Owner, remoteTag.Owner))
+	}
+
+	return drift
+}
+
+// describeLabel formats a label value for itemDrift.
+func describeLabel(value string, ok bool) string {
+	if !ok {
+		return "(not set)"
+	}
+	return fmt.Sprintf("%q", value)
+}
+
+// formatDrift renders the output of itemDrift for a diagnostic.
+func formatDrift(drift []string) string {
+	return "  " + strings.Join(drift, "\n  ")
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemDrift(t *testing.T) {
+	expected := lastAppliedItem{
+		Name: "1928 de Havilland DH-60GM",
+		Tag:  "USD:110,781|labels:env=prod&owner=hangar-2|owner:ws-1",
+	}
+
+	if drift := itemDrift(expected, expected); len(drift) != 0 {
+		t.Errorf("expected no drift, got %v", drift)
+	}
+
+	remote := lastAppliedItem{
+		Name: "1928 de Havilland DH-60G",
+		Tag:  "USD:99,000|labels:owner=hangar-3&site=north|owner:ws-1",
+	}
+
+	want := []string{
+		`name: expected "1928 de Havilland DH-60GM", found "1928 de Havilland DH-60G"`,
+		`tag: expected "USD:110,781", found "USD:99,000"`,
+		`labels.env: expected "prod", found (not set)`,
+		`labels.owner: expected "hangar-2", found "hangar-3"`,
+		`labels.site: expected (not set), found "north"`,
+	}
+	if drift := itemDrift(expected, remote); !reflect.DeepEqual(drift, want) {
+		t.Errorf("expected %#v, got %#v", want, drift)
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &convertPriceFunction{}
+
+// exchangeRatesAttributeTypes are the attributes of the rates argument of
+// convert_price.
+var exchangeRatesAttributeTypes = map[string]attr.Type{
+	"base":  types.StringType,
+	"rates": types.MapType{ElemType: types.NumberType},
+}
+
+// exchangeRatesArgument maps the rates argument of convert_price.
+type exchangeRatesArgument struct {
+	Base  string                `tfsdk:"base"`
+	Rates map[string]*big.Float `tfsdk:"rates"`
+}
+
+// NewConvertPriceFunction is a helper function to simplify the provider implementation.
+func NewConvertPriceFunction() function.Function {
+	return &convertPriceFunction{}
+}
+
+// convertPriceFunction is the function implementation.
+type convertPriceFunction struct{}
+
+// Metadata returns the function name.
+func (f *convertPriceFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "convert_price"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *convertPriceFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert a price tag to another currency",
+		Description: "Convert a tag of the form CURRENCY:amount, such as USD:79,420, to another currency. " +
+			"The result is rounded to the minor units of the currency, and any text after the amount is dropped. " +
+			"Rates are given as an object with a base currency and the rates of other currencies against it, such as " +
+			"{ base = \"USD\", rates = { EUR = 0.92 } }. Terraform does not configure providers before calling their functions, " +
+			"so the rates in the provider exchange_rates block are not used.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "tag",
+				Description: "The price tag to convert.",
+			},
+			function.StringParameter{
+				Name:        "currency",
+				Description: "The ISO 4217 code of the currency to convert to, such as EUR.",
+			},
+			function.ObjectParameter{
+				Name:           "rates",
+				Description:    "The exchange rates to use.",
+				AttributeTypes: exchangeRatesAttributeTypes,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the price.
+func (f *convertPriceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tag, currency string
+	var argument exchangeRatesArgument
+
+	resp.Error = req.Arguments.Get(ctx, &tag, &currency, &argument)
+	if resp.Error != nil {
+		return
+	}
+
+	price, err := parsePriceTag(tag)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	if !currencyCodePattern.MatchString(currency) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("currency %q is not an ISO 4217 currency code such as USD", currency))
+		return
+	}
+
+	rates, funcErr := functionExchangeRates(2, argument)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	amount, err := rates.convert(price.Amount, price.Currency, currency)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, canonicalPriceTag(currency, amount, roundingNearest, canonicalPriceFormat).String())
+}
+
+// functionExchangeRates returns the rates given as a function argument, which
+// is at the given argument position.
+func functionExchangeRates(argument int64, arg exchangeRatesArgument) (*exchangeRates, *function.FuncError) {
+	rates := make(map[string]*big.Rat, len(arg.Rates))
+	for currency, rate := range arg.Rates {
+		if rate == nil {
+			return nil, function.NewArgumentFuncError(argument, fmt.Sprintf("the rate for %s must not be null", currency))
+		}
+		rates[currency] = bigFloatRat(rate)
+	}
+
+	x, err := newExchangeRates(arg.Base, rates)
+	if err != nil {
+		return nil, function.NewArgumentFuncError(argument, err.Error())
+	}
+	return x, nil
+}
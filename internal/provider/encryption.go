@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Encrypted tags are stored by the inventory service as
+//
+//	enc:v1:<key id>:<base64url(nonce || ciphertext)>
+//
+// using AES-256-GCM, with the item name as additional data so a sealed tag
+// cannot be moved to another item. The key ID is derived from the key itself,
+// so the matching key can be picked during decryption while keys are rotated.
+const (
+	encryptedTagPrefix = "enc:v1:"
+
+	// encryptionKeyEnvVar is read when the encryption block does not name a
+	// key source.
+	encryptionKeyEnvVar = "INVENTORY_ENCRYPTION_KEY"
+)
+
+var (
+	errTagEncrypted    = errors.New("the item tag is encrypted, but no encryption block is configured for the provider")
+	errTagNotEncrypted = errors.New("the item tag is not encrypted, but an encryption block is configured for the provider, so it is not trusted")
+)
+
+// tagCipher encrypts and decrypts item tags. A nil tagCipher leaves tags as
+// plain text.
+type tagCipher struct {
+	primaryID string
+	keys      map[string]cipher.AEAD
+}
+
+// newTagCipher creates a tagCipher that encrypts with the primary key and can
+// decrypt with the primary key or any of the decryption keys.
+func newTagCipher(primary []byte, decryption [][]byte) (*tagCipher, error) {
+	c := &tagCipher{
+		keys: map[string]cipher.AEAD{},
+	}
+
+	for i, key := range append([][]byte{primary}, decryption...) {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		id := encryptionKeyID(key)
+		if i == 0 {
+			c.primaryID = id
+		}
+		c.keys[id] = aead
+	}
+
+	return c, nil
+}
+
+// parseEncryptionKey decodes a base64 encoded 256-bit key.
+func parseEncryptionKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("the key must be base64 encoded: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("the key must be 32 bytes long, got %d bytes", len(key))
+	}
+	return key, nil
+}
+
+// encryptionKeyID returns a short identifier for the key.
+func encryptionKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
+
+// seal encrypts the tag of the named item before it is sent to the inventory
+// service.
+func (c *tagCipher) seal(tag string, name string) (string, error) {
+	if c == nil {
+		return tag, nil
+	}
+
+	aead := c.keys[c.primaryID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(tag), []byte(name))
+
+	return encryptedTagPrefix + c.primaryID + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts the tag of the named item returned by the inventory service.
+// Without a cipher, tags that are not encrypted are returned unchanged. With a
+// cipher, only empty tags may be left unencrypted, as anyone who can write to
+// the inventory service could otherwise replace a sealed tag with plain text.
+func (c *tagCipher) open(stored string, name string) (string, error) {
+	if !strings.HasPrefix(stored, encryptedTagPrefix) {
+		if c != nil && stored != "" {
+			return "", errTagNotEncrypted
+		}
+		return stored, nil
+	}
+	if c == nil {
+		return "", errTagEncrypted
+	}
+
+	id, payload, found := strings.Cut(strings.TrimPrefix(stored, encryptedTagPrefix), ":")
+	if !found {
+		return "", errors.New("the encrypted item tag is malformed")
+	}
+
+	aead, ok := c.keys[id]
+	if !ok {
+		return "", fmt.Errorf("the item tag was encrypted with key %q, which is not one of the configured encryption keys", id)
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("the encrypted item tag is malformed")
+	}
+
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("the item tag could not be authenticated with key %q for an item named %q", id, name)
+	}
+
+	return string(plain), nil
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestTagCipher(t *testing.T) {
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+
+	oldCipher, err := newTagCipher(oldKey, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rotatedCipher, err := newTagCipher(newKey, [][]byte{oldKey})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	sealed, err := oldCipher.seal("EUR:12.50|labels:supplier=acme", "Floor Mats")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(sealed, encryptedTagPrefix) || strings.Contains(sealed, "acme") {
+		t.Fatalf("expected an encrypted tag, got %q", sealed)
+	}
+
+	// Tags written with a previous key are readable after rotation.
+	opened, err := rotatedCipher.open(sealed, "Floor Mats")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if opened != "EUR:12.50|labels:supplier=acme" {
+		t.Errorf("unexpected decrypted tag %q", opened)
+	}
+
+	// Tags written with the new key are not readable with only the old key.
+	sealed, err = rotatedCipher.seal("EUR:13.00", "Floor Mats")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := oldCipher.open(sealed, "Floor Mats"); err == nil {
+		t.Error("expected an error decrypting with an unknown key")
+	}
+
+	// Tampering is detected.
+	tampered := []byte(sealed)
+	idx := strings.LastIndex(sealed, ":") + 4
+	if tampered[idx] == 'A' {
+		tampered[idx] = 'B'
+	} else {
+		tampered[idx] = 'A'
+	}
+	if _, err := rotatedCipher.open(string(tampered), "Floor Mats"); err == nil {
+		t.Error("expected an error decrypting a tampered tag")
+	}
+
+	// Tags are bound to the item they were sealed for.
+	if _, err := rotatedCipher.open(sealed, "Warhead Soda"); err == nil {
+		t.Error("expected an error decrypting a tag copied to another item")
+	}
+
+	// Plain tags are not trusted once encryption is configured, except for
+	// empty tags.
+	if _, err := rotatedCipher.open("USD:2.99", "Floor Mats"); err != errTagNotEncrypted {
+		t.Errorf("expected %v, got %v", errTagNotEncrypted, err)
+	}
+	if opened, err := rotatedCipher.open("", "Floor Mats"); err != nil || opened != "" {
+		t.Errorf("expected an empty tag to pass through, got %q, %v", opened, err)
+	}
+
+	// Plain tags pass through, but encrypted tags need a cipher.
+	var disabled *tagCipher
+	if opened, err := disabled.open("USD:2.99", "Warhead Soda"); err != nil || opened != "USD:2.99" {
+		t.Errorf("expected plain tag to pass through, got %q, %v", opened, err)
+	}
+	if _, err := disabled.open(sealed, "Floor Mats"); err != errTagEncrypted {
+		t.Errorf("expected %v, got %v", errTagEncrypted, err)
+	}
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	valid := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	if _, err := parseEncryptionKey(valid + "\n"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	for _, invalid := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := parseEncryptionKey(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
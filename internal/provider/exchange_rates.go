@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// exchangeRates converts prices between currencies. Each rate is the number of
+// units of a currency that one unit of the base currency buys.
+type exchangeRates struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+// exchangeRatesFile is the format of the exchange_rates file, such as
+// {"base": "USD", "rates": {"EUR": 0.92, "GBP": 0.79}}.
+type exchangeRatesFile struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// newExchangeRates validates the rates against the base currency.
+func newExchangeRates(base string, rates map[string]*big.Rat) (*exchangeRates, error) {
+	if !currencyCodePattern.MatchString(base) {
+		return nil, fmt.Errorf("base currency %q is not an ISO 4217 currency code such as USD", base)
+	}
+
+	x := &exchangeRates{
+		base:  base,
+		rates: map[string]*big.Rat{base: big.NewRat(1, 1)},
+	}
+
+	for currency, rate := range rates {
+		if !currencyCodePattern.MatchString(currency) {
+			return nil, fmt.Errorf("currency %q is not an ISO 4217 currency code such as USD", currency)
+		}
+		if rate.Sign() <= 0 {
+			return nil, fmt.Errorf("the rate for %s must be positive, got %s", currency, rate.FloatString(6))
+		}
+		if currency == base && rate.Cmp(big.NewRat(1, 1)) != 0 {
+			return nil, fmt.Errorf("the rate for the base currency %s must be 1, got %s", currency, rate.FloatString(6))
+		}
+		x.rates[currency] = rate
+	}
+
+	return x, nil
+}
+
+// readExchangeRatesFile reads rates from a JSON file. The base currency in the
+// file, when present, must match base.
+func readExchangeRatesFile(name string, base string) (map[string]*big.Rat, error) {
+	contents, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var file exchangeRatesFile
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid exchange rates file: %w", err)
+	}
+
+	if file.Base != "" && file.Base != base {
+		return nil, fmt.Errorf("the file has base currency %s, but the base currency is %s", file.Base, base)
+	}
+
+	rates := make(map[string]*big.Rat, len(file.Rates))
+	for currency, n := range file.Rates {
+		rate, ok := new(big.Rat).SetString(n.String())
+		if !ok {
+			return nil, fmt.Errorf("invalid rate %q for %s", n, currency)
+		}
+		rates[currency] = rate
+	}
+
+	return rates, nil
+}
+
+// convert converts an amount between currencies.
+func (x *exchangeRates) convert(amount *big.Rat, from string, to string) (*big.Rat, error) {
+	fromRate, ok := x.rates[from]
+	if !ok {
+		return nil, fmt.Errorf("no exchange rate for %s, rates are available for %s", from, x.currencies())
+	}
+	toRate, ok := x.rates[to]
+	if !ok {
+		return nil, fmt.Errorf("no exchange rate for %s, rates are available for %s", to, x.currencies())
+	}
+
+	converted := new(big.Rat).Quo(amount, fromRate)
+	return converted.Mul(converted, toRate), nil
+}
+
+// currencies lists the currencies with rates.
+func (x *exchangeRates) currencies() string {
+	currencies := make([]string, 0, len(x.rates))
+	for c := range x.rates {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+	return strings.Join(currencies, ", ")
+}
+
+// baseAmount returns the price held by a tag in the base currency, rounded to
+// the minor units of the base currency. It is null when no rates are
+// configured, the tag is not a price or there is no rate for its currency.
+func (x *exchangeRates) baseAmount(tag types.String) types.Number {
+	if x == nil || tag.IsNull() {
+		return types.NumberNull()
+	}
+	if tag.IsUnknown() {
+		return types.NumberUnknown()
+	}
+
+	price, err := parsePriceTag(tag.ValueString())
+	if err != nil {
+		return types.NumberNull()
+	}
+
+	amount, err := x.convert(price.Amount, price.Currency, x.base)
+	if err != nil {
+		return types.NumberNull()
+	}
+
+	return types.NumberValue(ratBigFloat(roundAmount(amount, minorUnits(x.base), roundingNearest)))
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewExchangeRates(t *testing.T) {
+	tests := map[string]struct {
+		base    string
+		rates   map[string]*big.Rat
+		wantErr bool
+	}{
+		"valid":         {base: "USD", rates: map[string]*big.Rat{"EUR": big.NewRat(92, 100)}},
+		"base rate":     {base: "USD", rates: map[string]*big.Rat{"USD": big.NewRat(1, 1)}},
+		"bad base":      {base: "usd", wantErr: true},
+		"bad currency":  {base: "USD", rates: map[string]*big.Rat{"Euro": big.NewRat(1, 1)}, wantErr: true},
+		"zero rate":     {base: "USD", rates: map[string]*big.Rat{"EUR": new(big.Rat)}, wantErr: true},
+		"base not 1":    {base: "USD", rates: map[string]*big.Rat{"USD": big.NewRat(2, 1)}, wantErr: true},
+		"negative rate": {base: "USD", rates: map[string]*big.Rat{"EUR": big.NewRat(-1, 1)}, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newExchangeRates(tt.base, tt.rates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReadExchangeRatesFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(contents), 0o600); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return p
+	}
+
+	rates, err := readExchangeRatesFile(write("rates.json", `{"base": "USD", "rates": {"EUR": 0.92, "JPY": 151.3}}`), "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rates["EUR"].Cmp(big.NewRat(92, 100)) != 0 || rates["JPY"].Cmp(big.NewRat(1513, 10)) != 0 {
+		t.Errorf("expected exact rates, got %v", rates)
+	}
+
+	if _, err := readExchangeRatesFile(write("other.json", `{"base": "EUR", "rates": {}}`), "USD"); err == nil {
+		t.Error("expected an error for a mismatched base currency")
+	}
+	if _, err := readExchangeRatesFile(write("invalid.json", `{"rates": [1]}`), "USD"); err == nil {
+		t.Error("expected an error for an invalid file")
+	}
+	if _, err := readExchangeRatesFile(filepath.Join(dir, "missing.json"), "USD"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestExchangeRatesBaseAmount(t *testing.T) {
+	x, err := newExchangeRates("USD", map[string]*big.Rat{
+		"EUR": big.NewRat(92, 100),
+		"JPY": big.NewRat(150, 1),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		tag  types.String
+		want types.Number
+	}{
+		{tag: types.StringValue("USD:79,420"), want: types.NumberValue(big.NewFloat(79420))},
+		{tag: types.StringValue("EUR:92"), want: types.NumberValue(big.NewFloat(100))},
+		{tag: types.StringValue("JPY:1,000 each"), want: types.NumberValue(ratBigFloat(big.NewRat(667, 100)))},
+		{tag: types.StringValue("GBP:10"), want: types.NumberNull()},
+		{tag: types.StringValue("Vintage"), want: types.NumberNull()},
+		{tag: types.StringNull(), want: types.NumberNull()},
+		{tag: types.StringUnknown(), want: types.NumberUnknown()},
+	}
+
+	for _, tt := range tests {
+		if got := x.baseAmount(tt.tag); !got.Equal(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.tag, tt.want, got)
+		}
+	}
+
+	var none *exchangeRates
+	if got := none.baseAmount(types.StringValue("USD:1")); !got.IsNull() {
+		t.Errorf("expected null without rates, got %s", got)
+	}
+}
+
+func TestConvertPriceFunction(t *testing.T) {
+	f := NewConvertPriceFunction()
+	rates := types.ObjectValueMust(exchangeRatesAttributeTypes, map[string]attr.Value{
+		"base": types.StringValue("USD"),
+		"rates": types.MapValueMust(types.NumberType, map[string]attr.Value{
+			"EUR": types.NumberValue(big.NewFloat(0.92)),
+			"JPY": types.NumberValue(big.NewFloat(150)),
+		}),
+	})
+	args := func(tag, currency string) []attr.Value {
+		return []attr.Value{types.StringValue(tag), types.StringValue(currency), rates}
+	}
+
+	tests := []struct {
+		tag      string
+		currency string
+		want     string
+		wantErr  bool
+	}{
+		{tag: "USD:79,420", currency: "EUR", want: "EUR:73,066.40"},
+		{tag: "EUR:92", currency: "JPY", want: "JPY:15,000"},
+		{tag: "JPY:1,000 each", currency: "USD", want: "USD:6.67"},
+		{tag: "USD:1", currency: "GBP", wantErr: true},
+		{tag: "USD:1", currency: "eur", wantErr: true},
+		{tag: "Vintage", currency: "EUR", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		value, funcErr := runFunction(t, f, args(tt.tag, tt.currency))
+		if tt.wantErr {
+			if funcErr == nil {
+				t.Errorf("%q: expected an error", tt.tag)
+			}
+			continue
+		}
+		if funcErr != nil {
+			t.Errorf("%q: unexpected error: %s", tt.tag, funcErr)
+			continue
+		}
+		if want := types.StringValue(tt.want); !value.Equal(want) {
+			t.Errorf("%q: expected %s, got %s", tt.tag, want, value)
+		}
+	}
+}
+
+func TestFunctionExchangeRates(t *testing.T) {
+	x, funcErr := functionExchangeRates(2, exchangeRatesArgument{
+		Base:  "EUR",
+		Rates: map[string]*big.Float{"USD": big.NewFloat(1.25)},
+	})
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr)
+	}
+	amount, err := x.convert(big.NewRat(100, 1), "USD", "EUR")
+	if err != nil || amount.Cmp(big.NewRat(80, 1)) != 0 {
+		t.Errorf("expected 80, got %v %v", amount, err)
+	}
+
+	if _, funcErr := functionExchangeRates(2, exchangeRatesArgument{Base: "EUR", Rates: map[string]*big.Float{"USD": nil}}); funcErr == nil {
+		t.Error("expected an error for a null rate")
+	}
+}
+
+func TestConfigureExchangeRates(t *testing.T) {
+	ctx := context.Background()
+	p := &inventoryProvider{}
+
+	rates := func(rates map[string]attr.Value) *exchangeRatesModel {
+		return &exchangeRatesModel{
+			BaseCurrency: types.StringValue("USD"),
+			Rates:        types.MapValueMust(types.NumberType, rates),
+			File:         types.StringNull(),
+		}
+	}
+
+	x, diags := p.configureExchangeRates(ctx, rates(map[string]attr.Value{
+		"EUR": types.NumberValue(big.NewFloat(0.5)),
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if amount, err := x.convert(big.NewRat(1, 1), "USD", "EUR"); err != nil || amount.Cmp(big.NewRat(1, 2)) != 0 {
+		t.Errorf("expected 1/2, got %v %v", amount, err)
+	}
+
+	for name, rate := range map[string]attr.Value{
+		"null":    types.NumberNull(),
+		"unknown": types.NumberUnknown(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, diags := p.configureExchangeRates(ctx, rates(map[string]attr.Value{"EUR": rate}))
+			if !diags.HasError() {
+				t.Fatal("expected an error")
+			}
+			want := path.Root("exchange_rates").AtName("rates").AtMapKey("EUR")
+			if d, ok := diags[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(want) {
+				t.Errorf("expected an error on %s, got %v", want, diags)
+			}
+		})
+	}
+}
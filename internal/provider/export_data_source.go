@@ -0,0 +1,533 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/superorbital/inventory-service/client"
+	"gopkg.in/yaml.v3"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Formats rendered by the inventory_export data source.
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+	exportFormatYAML = "yaml"
+)
+
+// Columns of the inventory_export data source.
+const (
+	exportColumnID       = "id"
+	exportColumnName     = "name"
+	exportColumnTag      = "tag"
+	exportColumnLabels   = "labels"
+	exportColumnOwnerID  = "owner_id"
+	exportColumnCurrency = "currency"
+	exportColumnAmount   = "amount"
+)
+
+// exportColumns lists the supported columns, in their default order.
+var exportColumns = []string{
+	exportColumnID,
+	exportColumnName,
+	exportColumnTag,
+	exportColumnLabels,
+	exportColumnOwnerID,
+	exportColumnCurrency,
+	exportColumnAmount,
+}
+
+// defaultExportColumns are the columns exported when none are configured.
+var defaultExportColumns = []string{exportColumnID, exportColumnName, exportColumnTag}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &exportDataSource{}
+	_ datasource.DataSourceWithConfigure      = &exportDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &exportDataSource{}
+)
+
+// NewExportDataSource is a helper function to simplify the provider implementation.
+func NewExportDataSource() datasource.DataSource {
+	return &exportDataSource{}
+}
+
+// exportDataSource is the data source implementation.
+type exportDataSource struct {
+	client *client.Client
+	cipher *tagCipher
+}
+
+// exportDataSourceModel maps the data source schema data.
+type exportDataSourceModel struct {
+	Format         types.String     `tfsdk:"format"`
+	Columns        types.List       `tfsdk:"columns"`
+	PriceColumns   types.Bool       `tfsdk:"price_columns"`
+	SortBy         types.String     `tfsdk:"sort_by"`
+	SortDescending types.Bool       `tfsdk:"sort_descending"`
+	Filter         *itemFilterModel `tfsdk:"filter"`
+	Content        types.String     `tfsdk:"content"`
+	ItemCount      types.Int64      `tfsdk:"item_count"`
+}
+
+// exportRow is a single exported item.
+type exportRow struct {
+	item      client.Item
+	storedTag itemTag
+	// price is nil when the tag does not hold a price.
+	price *priceTag
+}
+
+// newExportRow decodes the tag and price of an item.
+func newExportRow(c *tagCipher, item client.Item) (exportRow, error) {
+	storedTag, err := decodeItemTag(c, item)
+	if err != nil {
+		return exportRow{}, err
+	}
+
+	row := exportRow{item: item, storedTag: storedTag}
+	if price, err := parsePriceTag(storedTag.Tag); err == nil {
+		row.price = &price
+	}
+	return row, nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *exportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	d.client = data.client
+	d.cipher = data.cipher
+}
+
+// Metadata returns the data source type name.
+func (d *exportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *exportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Render items as CSV, JSON or YAML, such as for a catalog snapshot written with the local_file resource.",
+		Attributes: map[string]schema.Attribute{
+			"format": schema.StringAttribute{
+				Description: "The format to render: csv, json or yaml.",
+				Required:    true,
+			},
+			"columns": schema.ListAttribute{
+				Description: "The columns to export, in order: " + strings.Join(exportColumns, ", ") + ". " +
+					"Defaults to " + strings.Join(defaultExportColumns, ", ") + ". " +
+					"The currency and amount columns are decoded from tags of the form CURRENCY:amount, and are empty for other tags.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"price_columns": schema.BoolAttribute{
+				Description: "Add the currency and amount columns, if they are not already exported.",
+				Optional:    true,
+			},
+			"sort_by": schema.StringAttribute{
+				Description: "The column to sort items by. Identifiers and amounts are compared as numbers, and items without a price sort last by currency or amount. Defaults to id.",
+				Optional:    true,
+			},
+			"sort_descending": schema.BoolAttribute{
+				Description: "Sort items in descending order.",
+				Optional:    true,
+			},
+			"filter": itemFilterAttribute(),
+			"content": schema.StringAttribute{
+				Description: "The rendered items.",
+				Computed:    true,
+			},
+			"item_count": schema.Int64Attribute{
+				Description: "The number of exported items.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the data source configuration.
+func (d *exportDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config exportDataSourceModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("format"), &config.Format)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("columns"), &config.Columns)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sort_by"), &config.SortBy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Format.IsNull() && !config.Format.IsUnknown() {
+		switch config.Format.ValueString() {
+		case exportFormatCSV, exportFormatJSON, exportFormatYAML:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("format"),
+				"Invalid Export Format",
+				fmt.Sprintf("The format must be %q, %q or %q, got %q.", exportFormatCSV, exportFormatJSON, exportFormatYAML, config.Format.ValueString()),
+			)
+		}
+	}
+
+	if !config.Columns.IsNull() && !config.Columns.IsUnknown() {
+		for i, v := range config.Columns.Elements() {
+			column, ok := v.(types.String)
+			if !ok || column.IsNull() || column.IsUnknown() {
+				continue
+			}
+			if !isExportColumn(column.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("columns").AtListIndex(i),
+					"Invalid Export Column",
+					fmt.Sprintf("The column must be one of %s, got %q.", strings.Join(exportColumns, ", "), column.ValueString()),
+				)
+			}
+		}
+	}
+
+	if !config.SortBy.IsNull() && !config.SortBy.IsUnknown() && !isExportColumn(config.SortBy.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sort_by"),
+			"Invalid Sort Column",
+			fmt.Sprintf("The sort column must be one of %s, got %q.", strings.Join(exportColumns, ", "), config.SortBy.ValueString()),
+		)
+	}
+
+	resp.Diagnostics.Append(validateItemFilter(ctx, req.Config, path.Root("filter"))...)
+}
+
+// isExportColumn reports whether a column is supported.
+func isExportColumn(column string) bool {
+	for _, c := range exportColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *exportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read export data source")
+	var state exportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	columns := defaultExportColumns
+	if !state.Columns.IsNull() {
+		columns = nil
+		resp.Diagnostics.Append(state.Columns.ElementsAs(ctx, &columns, false)...)
+	}
+	if state.PriceColumns.ValueBool() {
+		for _, column := range []string{exportColumnCurrency, exportColumnAmount} {
+			if !containsString(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	filter, diags := newItemFilter(ctx, state.Filter, path.Root("filter"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	items, err := findItems(ctx, d.client, client.FindItemsParams{})
+	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to List Items", "read", defaultReadTimeout, err))
+		return
+	}
+
+	rows := make([]exportRow, 0, len(items))
+	var undecryptable int
+	for _, item := range items {
+		row, err := newExportRow(d.cipher, item)
+		if err != nil {
+			tflog.Debug(ctx, "Skipping item with undecryptable tag", map[string]any{"id": item.Id, "error": err.Error()})
+			undecryptable++
+			continue
+		}
+		if !filter.matches(item, row.storedTag) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if undecryptable > 0 {
+		resp.Diagnostics.Append(undecryptableTagsWarning(undecryptable, "export"))
+	}
+
+	sortBy := exportColumnID
+	if !state.SortBy.IsNull() {
+		sortBy = state.SortBy.ValueString()
+	}
+	sortExportRows(rows, sortBy, state.SortDescending.ValueBool())
+
+	content, err := renderExport(state.Format.ValueString(), columns, rows)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Render Export",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Content = types.StringValue(content)
+	state.ItemCount = types.Int64Value(int64(len(rows)))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading export data source", map[string]any{"success": true, "count": len(rows)})
+}
+
+// containsString reports whether a slice holds a string.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sortExportRows orders rows by a column. Ties are broken by identifier, and
+// rows without a price sort last by currency or amount in either order.
+func sortExportRows(rows []exportRow, column string, descending bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+
+		var c int
+		switch column {
+		case exportColumnCurrency, exportColumnAmount:
+			if (a.price == nil) != (b.price == nil) {
+				return b.price == nil
+			}
+			if a.price != nil {
+				if column == exportColumnCurrency {
+					c = strings.Compare(a.price.Currency, b.price.Currency)
+				} else {
+					c = a.price.Amount.Cmp(b.price.Amount)
+				}
+			}
+		case exportColumnID:
+		default:
+			c = strings.Compare(a.text(column), b.text(column))
+		}
+
+		if c == 0 {
+			c = compareInt64(a.item.Id, b.item.Id)
+		}
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// compareInt64 returns -1, 0 or 1 as a is less than, equal to or greater
+// than b.
+func compareInt64(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// text returns the value of a column as text, which is empty when the row has
+// no value.
+func (r exportRow) text(column string) string {
+	switch column {
+	case exportColumnID:
+		return strconv.FormatInt(r.item.Id, 10)
+	case exportColumnName:
+		return r.item.Name
+	case exportColumnTag:
+		return r.storedTag.Tag
+	case exportColumnLabels:
+		// The key=value format never fails.
+		labels, _ := encodeLabels(r.storedTag.Labels, labelsFormatKeyValue)
+		return labels
+	case exportColumnOwnerID:
+		return r.storedTag.Owner
+	case exportColumnCurrency:
+		if r.price != nil {
+			return r.price.Currency
+		}
+	case exportColumnAmount:
+		if r.price != nil {
+			return exportAmount(r.price)
+		}
+	}
+	return ""
+}
+
+// exportAmount formats the amount of a price as a plain decimal number.
+func exportAmount(p *priceTag) string {
+	return formatAmount(p.Amount, p.Decimals, false, canonicalPriceFormat)
+}
+
+// renderExport renders rows in a format.
+func renderExport(format string, columns []string, rows []exportRow) (string, error) {
+	switch format {
+	case exportFormatCSV:
+		return renderExportCSV(columns, rows)
+	case exportFormatJSON:
+		return renderExportJSON(columns, rows)
+	case exportFormatYAML:
+		return renderExportYAML(columns, rows)
+	default:
+		return "", fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// renderExportCSV renders rows as CSV with a header line. Labels are rendered
+// as URL encoded key=value pairs.
+func renderExportCSV(columns []string, rows []exportRow) (string, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(columns); err != nil {
+		return "", err
+	}
+
+	record := make([]string, len(columns))
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = row.text(column)
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return b.String(), w.Error()
+}
+
+// renderExportJSON renders rows as a JSON array of objects, keeping the order
+// of the columns.
+func renderExportJSON(columns []string, rows []exportRow) (string, error) {
+	var b bytes.Buffer
+	b.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  {")
+		for j, column := range columns {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			key, _ := json.Marshal(column)
+			value, err := json.Marshal(row.value(column))
+			if err != nil {
+				return "", err
+			}
+			b.Write(key)
+			b.WriteString(": ")
+			b.Write(value)
+		}
+		b.WriteString("}")
+	}
+	if len(rows) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+	return b.String(), nil
+}
+
+// renderExportYAML renders rows as a YAML sequence of mappings, keeping the
+// order of the columns.
+func renderExportYAML(columns []string, rows []exportRow) (string, error) {
+	doc := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range rows {
+		mapping := &yaml.Node{Kind: yaml.MappingNode}
+		for _, column := range columns {
+			value := &yaml.Node{}
+			if err := value.Encode(row.value(column)); err != nil {
+				return "", err
+			}
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: column}, value)
+		}
+		doc.Content = append(doc.Content, mapping)
+	}
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// value returns the value of a column for structured formats. Amounts are
+// exact numbers, labels are objects and missing values are null.
+func (r exportRow) value(column string) any {
+	switch column {
+	case exportColumnID:
+		return r.item.Id
+	case exportColumnLabels:
+		if r.storedTag.Labels == nil {
+			return map[string]string{}
+		}
+		return r.storedTag.Labels
+	case exportColumnCurrency:
+		if r.price == nil {
+			return nil
+		}
+		return r.price.Currency
+	case exportColumnAmount:
+		if r.price == nil {
+			return nil
+		}
+		return exportNumber(exportAmount(r.price))
+	}
+	return r.text(column)
+}
+
+// exportNumber is a decimal number that is rendered exactly in JSON and YAML.
+type exportNumber string
+
+// MarshalJSON renders the number without quotes.
+func (n exportNumber) MarshalJSON() ([]byte, error) {
+	return []byte(n), nil
+}
+
+// MarshalYAML renders the number as a plain scalar.
+func (n exportNumber) MarshalYAML() (any, error) {
+	tag := "!!int"
+	if strings.Contains(string(n), ".") {
+		tag = "!!float"
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(n)}, nil
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestExportDataSource(t *testing.T) {
+	c := newItemListClient(t, []client.Item{
+		{Id: 3, Name: "1961 Jaguar E-Type", Tag: testTag("USD:79,420|labels:env=prod&team=cars")},
+		{Id: 1, Name: "1965 Shelby Cobra", Tag: testTag("USD:120,000.50")},
+		{Id: 2, Name: "Floor Mats, Rubber", Tag: testTag("Vintage")},
+	})
+
+	ctx := context.Background()
+	d := &exportDataSource{client: c}
+
+	columns := func(columns ...string) tftypes.Value {
+		values := make([]tftypes.Value, len(columns))
+		for i, column := range columns {
+			values[i] = tftypes.NewValue(tftypes.String, column)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+
+	read := func(t *testing.T, config map[string]tftypes.Value) exportDataSourceModel {
+		t.Helper()
+		resp := readDataSource(t, d, config)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var state exportDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		return state
+	}
+
+	for _, tt := range []struct {
+		name   string
+		config map[string]tftypes.Value
+		want   string
+	}{
+		{
+			name: "csv",
+			config: map[string]tftypes.Value{
+				"format": tftypes.NewValue(tftypes.String, "csv"),
+			},
+			want: "id,name,tag\n" +
+				"1,1965 Shelby Cobra,\"USD:120,000.50\"\n" +
+				"2,\"Floor Mats, Rubber\",Vintage\n" +
+				"3,1961 Jaguar E-Type,\"USD:79,420\"\n",
+		},
+		{
+			name: "csv labels",
+			config: map[string]tftypes.Value{
+				"format":  tftypes.NewValue(tftypes.String, "csv"),
+				"columns": columns("name", "labels"),
+				"sort_by": tftypes.NewValue(tftypes.String, "name"),
+			},
+			want: "name,labels\n" +
+				"1961 Jaguar E-Type,env=prod&team=cars\n" +
+				"1965 Shelby Cobra,\n" +
+				"\"Floor Mats, Rubber\",\n",
+		},
+		{
+			name: "json prices",
+			config: map[string]tftypes.Value{
+				"format":          tftypes.NewValue(tftypes.String, "json"),
+				"columns":         columns("id"),
+				"price_columns":   tftypes.NewValue(tftypes.Bool, true),
+				"sort_by":         tftypes.NewValue(tftypes.String, "amount"),
+				"sort_descending": tftypes.NewValue(tftypes.Bool, true),
+			},
+			want: "[\n" +
+				"  {\"id\": 1, \"currency\": \"USD\", \"amount\": 120000.50},\n" +
+				"  {\"id\": 3, \"currency\": \"USD\", \"amount\": 79420},\n" +
+				"  {\"id\": 2, \"currency\": null, \"amount\": null}\n" +
+				"]\n",
+		},
+		{
+			name: "yaml",
+			config: map[string]tftypes.Value{
+				"format":        tftypes.NewValue(tftypes.String, "yaml"),
+				"columns":       columns("id", "name", "labels"),
+				"price_columns": tftypes.NewValue(tftypes.Bool, true),
+				"filter": tftypes.NewValue(itemFilterType(), map[string]tftypes.Value{
+					"name_regex": tftypes.NewValue(tftypes.String, "Jaguar|Mats"),
+					"tag_regex":  tftypes.NewValue(tftypes.String, nil),
+					"labels":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				}),
+			},
+			want: "- id: 2\n" +
+				"  name: Floor Mats, Rubber\n" +
+				"  labels: {}\n" +
+				"  currency: null\n" +
+				"  amount: null\n" +
+				"- id: 3\n" +
+				"  name: 1961 Jaguar E-Type\n" +
+				"  labels:\n" +
+				"    env: prod\n" +
+				"    team: cars\n" +
+				"  currency: USD\n" +
+				"  amount: 79420\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			state := read(t, tt.config)
+			if state.Content.ValueString() != tt.want {
+				t.Errorf("expected content:\n%s\ngot:\n%s", tt.want, state.Content.ValueString())
+			}
+		})
+	}
+
+	t.Run("empty json", func(t *testing.T) {
+		state := read(t, map[string]tftypes.Value{
+			"format": tftypes.NewValue(tftypes.String, "json"),
+			"filter": tftypes.NewValue(itemFilterType(), map[string]tftypes.Value{
+				"name_regex": tftypes.NewValue(tftypes.String, "Porsche"),
+				"tag_regex":  tftypes.NewValue(tftypes.String, nil),
+				"labels":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			}),
+		})
+		if state.Content.ValueString() != "[]\n" || state.ItemCount.ValueInt64() != 0 {
+			t.Errorf("expected no items, got %q", state.Content.ValueString())
+		}
+	})
+}
+
+func TestExportDataSourceUndecryptable(t *testing.T) {
+	current, err := newTagCipher(bytes.Repeat([]byte{2}, 32), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rotated, err := newTagCipher(bytes.Repeat([]byte{1}, 32), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The second item was encrypted with a key that has been rotated out.
+	c := newItemListClient(t, []client.Item{
+		{Id: 1, Name: "1965 Shelby Cobra", Tag: testSealedTag(t, current, "USD:120,000.50", "1965 Shelby Cobra")},
+		{Id: 2, Name: "1961 Jaguar E-Type", Tag: testSealedTag(t, rotated, "USD:79,420", "1961 Jaguar E-Type")},
+	})
+
+	d := &exportDataSource{client: c, cipher: current}
+	resp := readDataSource(t, d, map[string]tftypes.Value{
+		"format":  tftypes.NewValue(tftypes.String, "csv"),
+		"columns": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "id")}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state exportDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	if want := "id\n1\n"; state.Content.ValueString() != want {
+		t.Errorf("expected content %q, got %q", want, state.Content.ValueString())
+	}
+
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || warnings[0].Detail() != "The tags of 1 items could not be decrypted, so they are not included in the export." {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
+
+func TestExportDataSourceValidateConfig(t *testing.T) {
+	resp := validateDataSourceConfig(t, &exportDataSource{}, map[string]tftypes.Value{
+		"format": tftypes.NewValue(tftypes.String, "xml"),
+		"columns": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "id"),
+			tftypes.NewValue(tftypes.String, "price"),
+		}),
+		"sort_by": tftypes.NewValue(tftypes.String, "owner"),
+	})
+
+	var summaries []string
+	for _, diag := range resp.Diagnostics {
+		summaries = append(summaries, diag.Summary())
+	}
+	want := []string{"Invalid Export Format", "Invalid Export Column", "Invalid Sort Column"}
+	if len(summaries) != len(want) {
+		t.Fatalf("expected %v, got %v", want, summaries)
+	}
+	for i := range want {
+		if summaries[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, summaries)
+		}
+	}
+}
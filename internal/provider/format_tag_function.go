@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &formatTagFunction{}
+
+// currencyCodePattern matches ISO 4217 currency codes.
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// NewFormatTagFunction is a helper function to simplify the provider implementation.
+func NewFormatTagFunction() function.Function {
+	return &formatTagFunction{}
+}
+
+// formatTagFunction is the function implementation.
+type formatTagFunction struct{}
+
+// Metadata returns the function name.
+func (f *formatTagFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_tag"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *formatTagFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Format a price tag",
+		Description: "Format a currency and an amount as a tag of the form CURRENCY:amount, such as USD:79,420, with thousands separated. " +
+			"Whole amounts have no decimals, other amounts are rounded to the minor units of the currency. " +
+			"An optional locale, such as de, selects the thousands and decimal separators. Tags formatted with a locale are parsed with parse_tag and the same locale.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "currency",
+				Description: "The ISO 4217 currency code, such as USD.",
+			},
+			function.NumberParameter{
+				Name:        "amount",
+				Description: "The amount.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "locale",
+			Description: "The locale of the separators in the amount. Defaults to en.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run formats the tag.
+func (f *formatTagFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var currency string
+	var amount *big.Float
+	var locales []string
+
+	resp.Error = req.Arguments.Get(ctx, &currency, &amount, &locales)
+	if resp.Error != nil {
+		return
+	}
+
+	if !currencyCodePattern.MatchString(currency) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("currency %q is not an ISO 4217 currency code such as USD", currency))
+		return
+	}
+
+	format, funcErr := functionPriceFormat(2, locales)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, canonicalPriceTag(currency, bigFloatRat(amount), roundingNearest, format).String())
+}
+
+// canonicalPriceTag returns the price tag for an amount. Whole amounts have no
+// decimals, other amounts are rounded to the minor units of the currency.
+func canonicalPriceTag(currency string, amount *big.Rat, rounding string, format priceFormat) priceTag {
+	decimals := minorUnits(currency)
+	rounded := roundAmount(amount, decimals, rounding)
+	if rounded.IsInt() {
+		decimals = 0
+	}
+
+	return priceTag{
+		Currency: currency,
+		Amount:   rounded,
+		Decimals: decimals,
+		Grouped:  true,
+		Format:   format,
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+const (
+	// idempotencyKeyHeader is sent with every AddItem request, so services
+	// that support it can deduplicate retried creates.
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// createRecoveryTimeout bounds the search for an item after an ambiguous
+	// create failure, which may happen after the create timeout has expired.
+	createRecoveryTimeout = time.Minute
+)
+
+// withIdempotencyKey adds the idempotency key header to a request.
+func withIdempotencyKey(key string) client.RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		req.Header.Set(idempotencyKeyHeader, key)
+		return nil
+	}
+}
+
+// addItem creates an item. When it fails, ambiguous reports whether the
+// service may have created the item anyway, such as after a timeout or a
+// server error.
+func addItem(ctx context.Context, c *client.Client, item client.NewItem, idempotencyKey string) (created client.Item, ambiguous bool, err error) {
+	itemResponse, err := c.AddItem(ctx, item, withIdempotencyKey(idempotencyKey))
+	if err != nil {
+		return created, true, err
+	}
+	defer itemResponse.Body.Close()
+
+	if itemResponse.StatusCode >= http.StatusInternalServerError {
+		return created, true, fmt.Errorf("unexpected HTTP error code received for Item: %s", itemResponse.Status)
+	}
+
+	if itemResponse.StatusCode >= http.StatusBadRequest {
+		return created, false, fmt.Errorf("unexpected HTTP error code received for Item: %s", itemResponse.Status)
+	}
+
+	if err := json.NewDecoder(itemResponse.Body).Decode(&created); err != nil {
+		return created, true, fmt.Errorf("invalid format received for Item: %w", err)
+	}
+
+	return created, false, nil
+}
+
+// createdItems returns the items created by a create request that failed
+// ambiguously. They are found by the idempotency key stored in their tags, so
+// identical items created before or by other configurations are not returned.
+func createdItems(ctx context.Context, c *client.Client, cipher *tagCipher, item client.NewItem, idempotencyKey string) ([]client.Item, error) {
+	// The create context may already have expired.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), createRecoveryTimeout)
+	defer cancel()
+
+	items, err := findItems(ctx, c, client.FindItemsParams{Tags: &[]string{*item.Tag}})
+	if err != nil {
+		return nil, err
+	}
+
+	var created []client.Item
+	for _, i := range items {
+		if i.Name != item.Name {
+			continue
+		}
+		storedTag, err := decodeItemTag(cipher, i)
+		if err != nil || storedTag.CreateKey != idempotencyKey {
+			continue
+		}
+		created = append(created, i)
+	}
+
+	sort.Slice(created, func(i, j int) bool {
+		return created[i].Id < created[j].Id
+	})
+
+	return created, nil
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+func TestCreateRecovery(t *testing.T) {
+	tag := "USD:2.99"
+	otherTag := "USD:2.99|create:other-key"
+	var mu sync.Mutex
+	items := []client.Item{
+		// Identical items that existed before the create, or were created by
+		// another configuration, must not be adopted.
+		{Id: 1, Name: "Warhead Soda", Tag: &tag},
+		{Id: 2, Name: "Warhead Soda", Tag: &otherTag},
+	}
+
+	// The service commits the item, but fails before it responds.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			if r.Header.Get(idempotencyKeyHeader) == "" {
+				t.Error("expected an idempotency key")
+			}
+			var newItem client.NewItem
+			if err := json.NewDecoder(r.Body).Decode(&newItem); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			items = append(items, client.Item{Id: int64(len(items) + 1), Name: newItem.Name, Tag: newItem.Tag})
+			w.WriteHeader(http.StatusBadGateway)
+		case http.MethodGet:
+			// Ignore the tags filter, so only the stored key tells the items apart.
+			_ = json.NewEncoder(w).Encode(items)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := context.Background()
+	encoded, err := itemTag{Tag: tag, CreateKey: "key"}.encode(labelsFormatKeyValue)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	item := client.NewItem{Name: "Warhead Soda", Tag: &encoded}
+
+	if _, ambiguous, err := addItem(ctx, c, item, "key"); err == nil || !ambiguous {
+		t.Fatalf("expected an ambiguous error, got %v (ambiguous: %t)", err, ambiguous)
+	}
+
+	created, err := createdItems(ctx, c, nil, item, "key")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(created) != 1 || created[0].Id != 3 {
+		t.Errorf("expected to recover item 3, got %#v", created)
+	}
+}
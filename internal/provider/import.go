@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+// Keys accepted in import IDs of the form <key>=<value>.
+const (
+	importKeyID   = "id"
+	importKeyName = "name"
+	importKeyTag  = "tag"
+)
+
+// maxImportCandidates bounds the number of candidates listed when an import
+// ID matches more than one item.
+const maxImportCandidates = 10
+
+// parseImportID splits an import ID into its key and value. A bare integer is
+// treated as an id.
+func parseImportID(importID string) (key string, value string, err error) {
+	key, value, found := strings.Cut(importID, "=")
+	if !found {
+		key, value = importKeyID, importID
+	}
+
+	switch key {
+	case importKeyID:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "", "", fmt.Errorf("ID should be an integer: %w", err)
+		}
+	case importKeyName, importKeyTag:
+		if value == "" {
+			return "", "", fmt.Errorf("the %s to import must not be empty", key)
+		}
+	default:
+		return "", "", fmt.Errorf("expected an integer ID or one of id=<id>, name=<name> or tag=<tag>, got %q", importID)
+	}
+
+	return key, value, nil
+}
+
+// importMatches returns the items whose name, or whose tag without reserved
+// segments, is exactly value, ordered by identifier. Items whose tags cannot
+// be decrypted never match a tag.
+func importMatches(c *tagCipher, items []client.Item, key string, value string) []client.Item {
+	var matches []client.Item
+	for _, item := range items {
+		switch key {
+		case importKeyName:
+			if item.Name != value {
+				continue
+			}
+		case importKeyTag:
+			storedTag, err := decodeItemTag(c, item)
+			if err != nil || storedTag.Tag != value {
+				continue
+			}
+		}
+		matches = append(matches, item)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Id < matches[j].Id
+	})
+
+	return matches
+}
+
+// resolveImportID returns the identifier of the item named by an import ID.
+func resolveImportID(ctx context.Context, c *client.Client, cipher *tagCipher, importID string) (int64, error) {
+	key, value, err := parseImportID(importID)
+	if err != nil {
+		return 0, err
+	}
+
+	if key == importKeyID {
+		return strconv.ParseInt(value, 10, 64)
+	}
+
+	items, err := findItems(ctx, c, client.FindItemsParams{})
+	if err != nil {
+		return 0, err
+	}
+
+	matches := importMatches(cipher, items, key, value)
+	switch len(matches) {
+	case 0:
+		var suggestions string
+		if key == importKeyName {
+			suggestions = formatSuggestions(suggestByName(items, value))
+		}
+		return 0, fmt.Errorf("no item has the %s %q%s", key, value, suggestions)
+	case 1:
+		return matches[0].Id, nil
+	default:
+		return 0, fmt.Errorf("%d items have the %s %q, import one of them by ID instead:\n\n%s",
+			len(matches), key, value, formatImportCandidates(cipher, matches))
+	}
+}
+
+// formatImportCandidates lists items for an ambiguous import, one per line.
+func formatImportCandidates(c *tagCipher, items []client.Item) string {
+	lines := make([]string, 0, min(len(items), maxImportCandidates)+1)
+	for i, item := range items {
+		if i == maxImportCandidates {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(items)-maxImportCandidates))
+			break
+		}
+
+		tag := "(encrypted)"
+		if storedTag, err := decodeItemTag(c, item); err == nil {
+			tag = strconv.Quote(storedTag.Tag)
+		}
+		lines = append(lines, fmt.Sprintf("id=%d name=%q tag=%s", item.Id, item.Name, tag))
+	}
+
+	return "  " + strings.Join(lines, "\n  ")
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+func TestParseImportID(t *testing.T) {
+	tests := []struct {
+		importID  string
+		wantKey   string
+		wantValue string
+		wantErr   bool
+	}{
+		{importID: "1000", wantKey: "id", wantValue: "1000"},
+		{importID: "id=1000", wantKey: "id", wantValue: "1000"},
+		{importID: "name=1953 Jaguar C-Type", wantKey: "name", wantValue: "1953 Jaguar C-Type"},
+		{importID: "tag=USD:79,420", wantKey: "tag", wantValue: "USD:79,420"},
+		{importID: "tag=a=b", wantKey: "tag", wantValue: "a=b"},
+		{importID: "abc", wantErr: true},
+		{importID: "id=abc", wantErr: true},
+		{importID: "name=", wantErr: true},
+		{importID: "owner=ws-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		key, value, err := parseImportID(tt.importID)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error", tt.importID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.importID, err)
+			continue
+		}
+		if key != tt.wantKey || value != tt.wantValue {
+			t.Errorf("%q: expected %s=%s, got %s=%s", tt.importID, tt.wantKey, tt.wantValue, key, value)
+		}
+	}
+}
+
+func TestImportMatches(t *testing.T) {
+	tag := func(s string) *string { return &s }
+	items := []client.Item{
+		{Id: 3, Name: "1953 Jaguar C-Type", Tag: tag("USD:79,420|owner:ws-1")},
+		{Id: 1, Name: "1953 Jaguar C-Type", Tag: tag("GBP:61,000")},
+		{Id: 2, Name: "1965 Shelby Cobra", Tag: tag("USD:79,420|labels:env=prod")},
+		{Id: 4, Name: "1965 Shelby Cobra", Tag: tag("enc:v1:0000000000000000:AAAA")},
+	}
+
+	ids := func(items []client.Item) []int64 {
+		var ids []int64
+		for _, item := range items {
+			ids = append(ids, item.Id)
+		}
+		return ids
+	}
+
+	if got := ids(importMatches(nil, items, importKeyName, "1953 Jaguar C-Type")); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("expected items 1 and 3 by name, got %v", got)
+	}
+	if got := ids(importMatches(nil, items, importKeyTag, "USD:79,420")); len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("expected items 2 and 3 by tag, got %v", got)
+	}
+	if got := ids(importMatches(nil, items, importKeyTag, "GBP:61,000")); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected item 1 by tag, got %v", got)
+	}
+	if got := importMatches(nil, items, importKeyName, "1961 Jaguar E-Type"); len(got) != 0 {
+		t.Errorf("expected no matches, got %v", ids(got))
+	}
+}
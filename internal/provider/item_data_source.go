@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/superorbital/inventory-service/client"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -25,14 +28,21 @@ func NewItemDataSource() datasource.DataSource {
 
 // itemDataSource is the data source implementation.
 type itemDataSource struct {
-	client *client.Client
+	client        *client.Client
+	cipher        *tagCipher
+	exchangeRates *exchangeRates
 }
 
 // itemDataSourceModel maps the data source schema data.
 type itemDataSourceModel struct {
-	ID   types.Int64  `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
-	Tag  types.String `tfsdk:"tag"`
+	ID           types.Int64    `tfsdk:"id"`
+	AllowMissing types.Bool     `tfsdk:"allow_missing"`
+	Exists       types.Bool     `tfsdk:"exists"`
+	Name         types.String   `tfsdk:"name"`
+	Tag          types.String   `tfsdk:"tag"`
+	Labels       types.Map      `tfsdk:"labels"`
+	BaseAmount   types.Number   `tfsdk:"base_amount"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Configure adds the provider configured client to the data source.
@@ -41,13 +51,14 @@ func (d *itemDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*inventoryProviderData)
 	if !ok {
 		tflog.Error(ctx, "Unable to prepare client")
 		return
 	}
-	d.client = client
-
+	d.client = data.client
+	d.cipher = data.cipher
+	d.exchangeRates = data.exchangeRates
 }
 
 // Metadata returns the data source type name.
@@ -56,7 +67,7 @@ func (d *itemDataSource) Metadata(_ context.Context, req datasource.MetadataRequ
 }
 
 // Schema defines the schema for the data source.
-func (d *itemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *itemDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Fetch an item.",
 		Attributes: map[string]schema.Attribute{
@@ -64,6 +75,14 @@ func (d *itemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Description: "Identifier for this inventory item.",
 				Required:    true,
 			},
+			"allow_missing": schema.BoolAttribute{
+				Description: "Succeed when the item does not exist, with exists set to false and the other attributes null. Other errors still fail.",
+				Optional:    true,
+			},
+			"exists": schema.BoolAttribute{
+				Description: "Whether the item exists.",
+				Computed:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "The name for this inventory item.",
 				Computed:    true,
@@ -72,6 +91,19 @@ func (d *itemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Description: "The tag for this inventory item.",
 				Computed:    true,
 			},
+			"labels": schema.MapAttribute{
+				Description: "The labels decoded from the tag for this inventory item.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"base_amount": schema.NumberAttribute{
+				Description: "The price held by the tag converted to the base currency of the provider exchange_rates block. " +
+					"Null when no rates are configured, the tag is not a price or there is no rate for its currency.",
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
@@ -82,18 +114,49 @@ func (d *itemDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	var state itemDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	itemResponse, err := d.client.FindItemById(ctx, state.ID.ValueInt64())
 	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Read Item", "read", readTimeout, err))
+		return
+	}
+
+	defer itemResponse.Body.Close()
+
+	if itemResponse.StatusCode == http.StatusNotFound && state.AllowMissing.ValueBool() {
+		state.Exists = types.BoolValue(false)
+		state.Name = types.StringNull()
+		state.Tag = types.StringNull()
+		state.Labels = types.MapNull(types.StringType)
+		state.BaseAmount = types.NumberNull()
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		tflog.Debug(ctx, "Finished reading item data source", map[string]any{"success": true, "exists": false})
+		return
+	}
+
+	if itemResponse.StatusCode == http.StatusNotFound {
 		resp.Diagnostics.AddError(
-			"Unable to Read Item",
-			err.Error(),
+			"Item Not Found",
+			fmt.Sprintf("Item %d does not exist.%s", state.ID.ValueInt64(), d.suggestions(ctx, state.ID.ValueInt64())),
 		)
 		return
 	}
 
 	var newItem client.Item
-	if itemResponse.StatusCode != 200 {
+	if itemResponse.StatusCode != http.StatusOK {
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP error code received for Item",
 			itemResponse.Status,
@@ -110,13 +173,43 @@ func (d *itemDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	// Map response body to model
+	storedTag, err := decodeItemTag(d.cipher, newItem)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Decrypt Item Tag",
+			fmt.Sprintf("The tag for item %d could not be decrypted: %s", newItem.Id, err),
+		)
+		return
+	}
+	labels, diags := storedLabels(ctx, storedTag.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	state = itemDataSourceModel{
-		ID:   types.Int64Value(newItem.Id),
-		Name: types.StringValue(newItem.Name),
-		Tag:  types.StringValue(*newItem.Tag),
+		ID:           types.Int64Value(newItem.Id),
+		AllowMissing: state.AllowMissing,
+		Exists:       types.BoolValue(true),
+		Name:         types.StringValue(newItem.Name),
+		Tag:          types.StringValue(storedTag.Tag),
+		Labels:       labels,
+		BaseAmount:   d.exchangeRates.baseAmount(types.StringValue(storedTag.Tag)),
+		Timeouts:     state.Timeouts,
 	}
 
 	// Set state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	tflog.Debug(ctx, "Finished reading item data source", map[string]any{"success": true})
 }
+
+// suggestions lists the items with identifiers close to a missing one. Items
+// are only suggested on a best effort basis, so listing errors are ignored.
+func (d *itemDataSource) suggestions(ctx context.Context, id int64) string {
+	items, err := findItems(ctx, d.client, client.FindItemsParams{})
+	if err != nil {
+		tflog.Debug(ctx, "Unable to list items for suggestions", map[string]any{"error": err.Error()})
+		return ""
+	}
+	return formatSuggestions(suggestByID(items, id))
+}
@@ -1,8 +1,16 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -29,6 +37,103 @@ data "inventory_item" "test" {
 					resource.TestCheckResourceAttrSet("data.inventory_item.test", "id"),
 				),
 			},
+			{
+				Config: providerConfig + `
+resource "inventory_item" "labeled" {
+  name = "2022 Mustang Shelby GT500"
+  tag = "USD:79,420"
+  labels = {
+    env = "showroom"
+  }
+}
+
+data "inventory_item" "labeled" {
+	id = inventory_item.labeled.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.inventory_item.labeled", "tag", "USD:79,420"),
+					resource.TestCheckResourceAttr("data.inventory_item.labeled", "labels.env", "showroom"),
+					resource.TestCheckResourceAttr("data.inventory_item.labeled", "exists", "true"),
+					// No exchange rates are configured
+					resource.TestCheckNoResourceAttr("data.inventory_item.labeled", "base_amount"),
+				),
+			},
 		},
 	})
 }
+
+func TestItemDataSourceAllowMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/items/1":
+			tag := "USD:79,420"
+			_ = json.NewEncoder(w).Encode(client.Item{Id: 1, Name: "1965 Shelby Cobra", Tag: &tag})
+		case "/items/2":
+			w.WriteHeader(http.StatusNotFound)
+		case "/items":
+			_ = json.NewEncoder(w).Encode([]client.Item{{Id: 1, Name: "1965 Shelby Cobra"}})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := context.Background()
+	d := &itemDataSource{client: c}
+
+	tests := map[string]struct {
+		id           int64
+		allowMissing any
+		wantErr      string
+		wantExists   bool
+	}{
+		"exists":                {id: 1, allowMissing: true, wantExists: true},
+		"missing":               {id: 2, allowMissing: true},
+		"missing not allowed":   {id: 2, wantErr: "Did you mean:\n  id=1 name=\"1965 Shelby Cobra\""},
+		"service error":         {id: 3, allowMissing: true, wantErr: "500"},
+		"exists without option": {id: 1, wantExists: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := readDataSource(t, d, map[string]tftypes.Value{
+				"id":            tftypes.NewValue(tftypes.Number, tt.id),
+				"allow_missing": tftypes.NewValue(tftypes.Bool, tt.allowMissing),
+			})
+			if tt.wantErr != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.wantErr) {
+					t.Errorf("expected the error to contain %q, got %q", tt.wantErr, detail)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var state itemDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if state.Exists.ValueBool() != tt.wantExists {
+				t.Errorf("expected exists %t, got %s", tt.wantExists, state.Exists)
+			}
+			if state.ID.ValueInt64() != tt.id {
+				t.Errorf("expected id %d, got %s", tt.id, state.ID)
+			}
+			if tt.wantExists != !state.Name.IsNull() || tt.wantExists != !state.Tag.IsNull() {
+				t.Errorf("expected name and tag to be null only when missing, got %s %s", state.Name, state.Tag)
+			}
+		})
+	}
+}
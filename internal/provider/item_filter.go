@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// itemFilterModel maps the filter attribute of the data sources that
+// aggregate items.
+type itemFilterModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	TagRegex  types.String `tfsdk:"tag_regex"`
+	Labels    types.Map    `tfsdk:"labels"`
+}
+
+// itemFilterAttribute defines the schema for itemFilterModel.
+func itemFilterAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Only include items matching every condition of this filter.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only include items whose name matches this regular expression.",
+				Optional:    true,
+			},
+			"tag_regex": schema.StringAttribute{
+				Description: "Only include items whose tag, not including labels or the owner ID, matches this regular expression.",
+				Optional:    true,
+			},
+			"labels": schema.MapAttribute{
+				Description: "Only include items carrying all of these labels.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// validateItemFilter checks the regular expressions of the filter attribute at
+// the given path.
+func validateItemFilter(ctx context.Context, config tfsdk.Config, filterPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, name := range []string{"name_regex", "tag_regex"} {
+		var value types.String
+		diags.Append(config.GetAttribute(ctx, filterPath.AtName(name), &value)...)
+		if diags.HasError() {
+			return diags
+		}
+		_, d := compileRegexAttribute(value, filterPath.AtName(name))
+		diags.Append(d...)
+	}
+	return diags
+}
+
+// itemFilter selects items by name, tag and labels.
+type itemFilter struct {
+	name   *regexp.Regexp
+	tag    *regexp.Regexp
+	labels map[string]string
+}
+
+// newItemFilter compiles the filter attribute at the given path. A nil model
+// matches every item.
+func newItemFilter(ctx context.Context, m *itemFilterModel, filterPath path.Path) (itemFilter, diag.Diagnostics) {
+	var f itemFilter
+	var diags diag.Diagnostics
+	if m == nil {
+		return f, diags
+	}
+
+	var d diag.Diagnostics
+	f.name, d = compileRegexAttribute(m.NameRegex, filterPath.AtName("name_regex"))
+	diags.Append(d...)
+	f.tag, d = compileRegexAttribute(m.TagRegex, filterPath.AtName("tag_regex"))
+	diags.Append(d...)
+	if !m.Labels.IsNull() {
+		diags.Append(m.Labels.ElementsAs(ctx, &f.labels, false)...)
+	}
+	return f, diags
+}
+
+// matchesName reports whether the name of an item matches the filter, which
+// can be checked before its tag is decrypted.
+func (f itemFilter) matchesName(item client.Item) bool {
+	return f.name == nil || f.name.MatchString(item.Name)
+}
+
+// matches reports whether an item and its decoded tag match the filter.
+func (f itemFilter) matches(item client.Item, storedTag itemTag) bool {
+	if !f.matchesName(item) {
+		return false
+	}
+	if f.tag != nil && !f.tag.MatchString(storedTag.Tag) {
+		return false
+	}
+	for k, v := range f.labels {
+		if got, ok := storedTag.Labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// leasePrivateKey is the private data key holding the leased item ID, so the
+// item can be deleted when the lease is closed.
+const leasePrivateKey = "lease"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &itemLeaseEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &itemLeaseEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &itemLeaseEphemeralResource{}
+)
+
+// NewItemLeaseEphemeralResource is a helper function to simplify the provider implementation.
+func NewItemLeaseEphemeralResource() ephemeral.EphemeralResource {
+	return &itemLeaseEphemeralResource{}
+}
+
+// itemLeaseEphemeralResource is the ephemeral resource implementation.
+type itemLeaseEphemeralResource struct {
+	client        *client.Client
+	defaultLabels map[string]string
+	labelsFormat  string
+	ownerID       string
+	cipher        *tagCipher
+	waiter        *consistencyWaiter
+}
+
+// itemLeaseEphemeralResourceModel maps the ephemeral resource schema data.
+type itemLeaseEphemeralResourceModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Tag  types.String `tfsdk:"tag"`
+}
+
+// itemLease is the lease recorded in private data.
+type itemLease struct {
+	ID int64 `json:"id"`
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *itemLeaseEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	e.client = data.client
+	e.defaultLabels = data.defaultLabels
+	e.labelsFormat = data.labelsFormat
+	e.ownerID = data.ownerID
+	e.cipher = data.cipher
+	e.waiter = data.waiter
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *itemLeaseEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item_lease"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *itemLeaseEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create a temporary item that exists only for the duration of a Terraform run and is never stored in state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Identifier for the leased item.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name for the leased item.",
+				Required:    true,
+			},
+			"tag": schema.StringAttribute{
+				Description: "The tag for the leased item.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Open creates the leased item.
+func (e *itemLeaseEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	tflog.Debug(ctx, "Preparing to open item lease")
+	var data itemLeaseEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultCreateTimeout)
+	defer cancel()
+
+	// Leased items carry the provider owner ID, so leases that could not be
+	// closed show up in inventory_orphans.
+	tag := itemTag{
+		Tag:    data.Tag.ValueString(),
+		Labels: e.defaultLabels,
+		Owner:  e.ownerID,
+	}
+	encoded, err := tag.encode(e.labelsFormat)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Encode Item Labels",
+			err.Error(),
+		)
+		return
+	}
+	sealed, err := e.cipher.seal(encoded, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Encrypt Item Tag",
+			err.Error(),
+		)
+		return
+	}
+
+	idempotencyKey, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Item",
+			"Could not generate an idempotency key: "+err.Error(),
+		)
+		return
+	}
+
+	item := client.NewItem{
+		Name: data.Name.ValueString(),
+		Tag:  &sealed,
+	}
+	newItem, _, err := addItem(ctx, e.client, item, idempotencyKey)
+	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Create Item", "create", defaultCreateTimeout, err))
+		return
+	}
+
+	// Close is not called when Open fails, so delete the item if any later
+	// step fails.
+	defer func() {
+		if !resp.Diagnostics.HasError() {
+			return
+		}
+		// The open context may already have expired.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultDeleteTimeout)
+		defer cancel()
+
+		if err := deleteItem(ctx, e.client, newItem.Id); err != nil {
+			tflog.Warn(ctx, "Unable to delete leased item after a failed open", map[string]any{"id": newItem.Id, "error": err.Error()})
+		}
+	}()
+
+	b, err := json.Marshal(itemLease{ID: newItem.Id})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Record Item Lease", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, leasePrivateKey, b)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.Int64Value(newItem.Id)
+	data.Name = types.StringValue(newItem.Name)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = e.waiter.wait(ctx, "item to be created", itemExistsCheck(e.client, newItem.Id))
+	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Confirm Item Creation", "create", defaultCreateTimeout, err))
+		return
+	}
+	tflog.Debug(ctx, "Opened item lease", map[string]any{"success": true, "id": newItem.Id})
+}
+
+// Close deletes the leased item. Failures are reported as warnings, as the
+// run has otherwise succeeded.
+func (e *itemLeaseEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	tflog.Debug(ctx, "Preparing to close item lease")
+	b, diags := req.Private.GetKey(ctx, leasePrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(b) == 0 {
+		return
+	}
+
+	var lease itemLease
+	if err := json.Unmarshal(b, &lease); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Private Data for Item Lease",
+			"The leased item could not be read: "+err.Error(),
+		)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultDeleteTimeout)
+	defer cancel()
+
+	if err := deleteItem(ctx, e.client, lease.ID); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Delete Leased Item",
+			fmt.Sprintf("Item %d could not be deleted and must be cleaned up separately, for example with the inventory_orphans data source: %s", lease.ID, err),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Closed item lease", map[string]any{"success": true, "id": lease.ID})
+}
+
+// deleteItem deletes an item. Items that no longer exist are not an error.
+func deleteItem(ctx context.Context, c *client.Client, id int64) error {
+	itemResponse, err := c.DeleteItem(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer itemResponse.Body.Close()
+
+	if itemResponse.StatusCode >= http.StatusBadRequest && itemResponse.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("unexpected HTTP error code received for Item: %s", itemResponse.Status)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestItemLeaseEphemeralResource(t *testing.T) {
+	var mu sync.Mutex
+	items := map[int64]client.Item{}
+	failDelete := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var id int64
+		_, _ = fmt.Sscanf(r.URL.Path, "/items/%d", &id)
+
+		switch r.Method {
+		case http.MethodPost:
+			var newItem client.NewItem
+			if err := json.NewDecoder(r.Body).Decode(&newItem); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			item := client.Item{Id: int64(len(items) + 1), Name: newItem.Name, Tag: newItem.Tag}
+			items[item.Id] = item
+			_ = json.NewEncoder(w).Encode(item)
+		case http.MethodGet:
+			item, ok := items[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(item)
+		case http.MethodDelete:
+			if failDelete {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			delete(items, id)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	provider, schemas := newTestProviderServer(t, server.URL, map[string]tftypes.Value{
+		"owner_id": tftypes.NewValue(tftypes.String, "ws-1"),
+	})
+	configType := schemas.EphemeralResourceSchemas["inventory_item_lease"].ValueType()
+
+	open := func(t *testing.T) *tfprotov6.OpenEphemeralResourceResponse {
+		t.Helper()
+		config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+			"name": tftypes.NewValue(tftypes.String, "Scratch Soda"),
+			"tag":  tftypes.NewValue(tftypes.String, "USD:2.99"),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := provider.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+			TypeName: "inventory_item_lease",
+			Config:   &config,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range resp.Diagnostics {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+		return resp
+	}
+
+	closeLease := func(t *testing.T, private []byte) *tfprotov6.CloseEphemeralResourceResponse {
+		t.Helper()
+		resp, err := provider.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+			TypeName: "inventory_item_lease",
+			Private:  private,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	t.Run("open and close", func(t *testing.T) {
+		resp := open(t)
+
+		result, err := resp.Result.Unmarshal(configType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var attributes map[string]tftypes.Value
+		if err := result.As(&attributes); err != nil {
+			t.Fatal(err)
+		}
+		if !attributes["id"].IsKnown() || attributes["id"].IsNull() {
+			t.Errorf("expected a known id, got %s", attributes["id"])
+		}
+
+		mu.Lock()
+		if len(items) != 1 {
+			t.Errorf("expected 1 item, got %d", len(items))
+		}
+		for _, item := range items {
+			if !strings.HasSuffix(*item.Tag, "|owner:ws-1") {
+				t.Errorf("expected the item to carry the owner ID, got %q", *item.Tag)
+			}
+		}
+		mu.Unlock()
+
+		closeResp := closeLease(t, resp.Private)
+		for _, d := range closeResp.Diagnostics {
+			t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+
+		mu.Lock()
+		if len(items) != 0 {
+			t.Errorf("expected the item to be deleted, got %v", items)
+		}
+		mu.Unlock()
+	})
+
+	t.Run("failed cleanup", func(t *testing.T) {
+		resp := open(t)
+
+		mu.Lock()
+		failDelete = true
+		mu.Unlock()
+
+		closeResp := closeLease(t, resp.Private)
+		if len(closeResp.Diagnostics) != 1 || closeResp.Diagnostics[0].Severity != tfprotov6.DiagnosticSeverityWarning {
+			t.Errorf("expected a single warning, got %v", closeResp.Diagnostics)
+		}
+	})
+}
+
+func TestItemLeaseEphemeralResourceFailedOpen(t *testing.T) {
+	var mu sync.Mutex
+	var created, deleted []int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			var newItem client.NewItem
+			if err := json.NewDecoder(r.Body).Decode(&newItem); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			item := client.Item{Id: int64(len(created) + 1), Name: newItem.Name, Tag: newItem.Tag}
+			created = append(created, item.Id)
+			_ = json.NewEncoder(w).Encode(item)
+		case http.MethodDelete:
+			var id int64
+			_, _ = fmt.Sscanf(r.URL.Path, "/items/%d", &id)
+			deleted = append(deleted, id)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := context.Background()
+	e := &itemLeaseEphemeralResource{client: c}
+
+	var schemaResp ephemeral.SchemaResponse
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	configType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected schema type %s", schemaResp.Schema.Type())
+	}
+
+	// The response has no private data to record the lease in, so the open
+	// fails after the item has been created.
+	var resp ephemeral.OpenResponse
+	e.Open(ctx, ephemeral.OpenRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.Number, nil),
+			"name": tftypes.NewValue(tftypes.String, "Scratch Soda"),
+			"tag":  tftypes.NewValue(tftypes.String, nil),
+		})},
+	}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(created) != 1 || len(deleted) != 1 || deleted[0] != created[0] {
+		t.Errorf("expected the created item to be deleted, created %v and deleted %v", created, deleted)
+	}
+}
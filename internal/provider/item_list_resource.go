@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &itemListResource{}
+	_ list.ListResourceWithConfigure = &itemListResource{}
+)
+
+// NewItemListResource is a helper function to simplify the provider implementation.
+func NewItemListResource() list.ListResource {
+	return &itemListResource{}
+}
+
+// itemListResource is the list resource implementation. It shares its type
+// name and configuration with the item resource.
+type itemListResource struct {
+	itemResource
+}
+
+// itemListResourceModel maps the list resource config schema data.
+type itemListResourceModel struct {
+	Name types.String `tfsdk:"name"`
+	Tag  types.String `tfsdk:"tag"`
+}
+
+// ListResourceConfigSchema defines the schema for the list block.
+func (r *itemListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List existing items, so they can be imported.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Only list items with exactly this name.",
+				Optional:    true,
+			},
+			"tag": schema.StringAttribute{
+				Description: "Only list items with exactly this tag, not including labels or the owner ID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// List streams the items matching the filters.
+func (r *itemListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	tflog.Debug(ctx, "Preparing to list item resources")
+	var config itemListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+
+	items, err := findItems(ctx, r.client, client.FindItemsParams{})
+	if err != nil {
+		cancel()
+		diags.Append(clientErrorDiagnostic("Unable to List Items", "read", defaultReadTimeout, err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Id < items[j].Id
+	})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		defer cancel()
+
+		var count int64
+		for _, item := range items {
+			if req.Limit > 0 && count >= req.Limit {
+				break
+			}
+
+			if !config.Name.IsNull() && item.Name != config.Name.ValueString() {
+				continue
+			}
+
+			storedTag, err := decodeItemTag(r.cipher, item)
+			if err != nil {
+				tflog.Debug(ctx, "Skipping item with undecryptable tag", map[string]any{"id": item.Id, "error": err.Error()})
+				continue
+			}
+			if !config.Tag.IsNull() && storedTag.Tag != config.Tag.ValueString() {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = item.Name
+			result.Diagnostics.Append(setIdentity(ctx, result.Identity, item)...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(r.setListedItem(ctx, result, item, storedTag)...)
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+		tflog.Debug(ctx, "Finished listing item resources", map[string]any{"success": true, "count": count})
+	}
+}
+
+// setListedItem sets the resource data of a list result to the item, as Read
+// would after the item was imported.
+func (r *itemListResource) setListedItem(ctx context.Context, result list.ListResult, item client.Item, storedTag itemTag) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	labels, d := r.stateLabels(ctx, types.MapNull(types.StringType), storedTag.Labels)
+	diags.Append(d...)
+	labelsAll, d := storedLabels(ctx, storedTag.Labels)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(result.Resource.Set(ctx, itemResourceModel{
+		ID:                types.Int64Value(item.Id),
+		Name:              types.StringValue(item.Name),
+		Tag:               tagValue(types.StringNull(), storedTag.Tag),
+		Labels:            labels,
+		LabelsAll:         labelsAll,
+		OwnerID:           ownerIDValue(storedTag.Owner),
+		BaseAmount:        r.exchangeRates.baseAmount(tagValue(types.StringNull(), storedTag.Tag)),
+		ConflictDetection: types.BoolNull(),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"read":   types.StringType,
+				"update": types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	})...)
+
+	return diags
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestItemListResource(t *testing.T) {
+	c := newItemListClient(t, []client.Item{
+		{Id: 3, Name: "1953 Jaguar C-Type", Tag: testTag("USD:79,420|labels:env=prod|owner:ws-1")},
+		{Id: 1, Name: "1965 Shelby Cobra", Tag: testTag("USD:79,420")},
+		{Id: 2, Name: "1953 Jaguar C-Type", Tag: testTag("GBP:61,000")},
+	})
+
+	ctx := context.Background()
+	r := &itemListResource{itemResource{client: c}}
+
+	var configSchema list.ListResourceSchemaResponse
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchema)
+	var resourceSchema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	var identitySchema resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+
+	tests := map[string]struct {
+		name    any
+		tag     any
+		limit   int64
+		wantIDs []int64
+	}{
+		"all":   {wantIDs: []int64{1, 2, 3}},
+		"name":  {name: "1953 Jaguar C-Type", wantIDs: []int64{2, 3}},
+		"tag":   {tag: "USD:79,420", wantIDs: []int64{1, 3}},
+		"both":  {name: "1953 Jaguar C-Type", tag: "USD:79,420", wantIDs: []int64{3}},
+		"limit": {limit: 2, wantIDs: []int64{1, 2}},
+		"none":  {name: "1961 Jaguar E-Type"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := list.ListRequest{
+				Config: tfsdk.Config{
+					Schema: configSchema.Schema,
+					Raw: tftypes.NewValue(configSchema.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+						"name": tftypes.NewValue(tftypes.String, tt.name),
+						"tag":  tftypes.NewValue(tftypes.String, tt.tag),
+					}),
+				},
+				IncludeResource:        true,
+				Limit:                  tt.limit,
+				ResourceSchema:         resourceSchema.Schema,
+				ResourceIdentitySchema: identitySchema.IdentitySchema,
+			}
+
+			var stream list.ListResultsStream
+			r.List(ctx, req, &stream)
+
+			var ids []int64
+			for result := range stream.Results {
+				for _, d := range result.Diagnostics {
+					t.Errorf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+				}
+
+				var identity itemIdentityModel
+				result.Diagnostics.Append(result.Identity.Get(ctx, &identity)...)
+				var state itemResourceModel
+				result.Diagnostics.Append(result.Resource.Get(ctx, &state)...)
+				if result.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+				}
+
+				if identity.ID != state.ID || identity.Name != state.Name || result.DisplayName != state.Name.ValueString() {
+					t.Errorf("expected identity and display name to match the item, got %v %v %q", identity, state, result.DisplayName)
+				}
+				if state.ID.ValueInt64() == 3 && (state.Tag.ValueString() != "USD:79,420" || state.OwnerID.ValueString() != "ws-1") {
+					t.Errorf("expected the tag to be decoded, got %v", state)
+				}
+				ids = append(ids, state.ID.ValueInt64())
+			}
+
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("expected items %v, got %v", tt.wantIDs, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("expected items %v, got %v", tt.wantIDs, ids)
+				}
+			}
+		})
+	}
+}
@@ -3,25 +3,37 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/superorbital/inventory-service/client"
 
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &itemResource{}
-	_ resource.ResourceWithConfigure   = &itemResource{}
-	_ resource.ResourceWithImportState = &itemResource{}
+	_ resource.Resource                 = &itemResource{}
+	_ resource.ResourceWithConfigure    = &itemResource{}
+	_ resource.ResourceWithImportState  = &itemResource{}
+	_ resource.ResourceWithModifyPlan   = &itemResource{}
+	_ resource.ResourceWithIdentity     = &itemResource{}
+	_ resource.ResourceWithUpgradeState = &itemResource{}
+	_ resource.ResourceWithMoveState    = &itemResource{}
 )
 
 // NewItemResource is a helper function to simplify the provider implementation.
@@ -31,14 +43,32 @@ func NewItemResource() resource.Resource {
 
 // itemResource is the resource implementation.
 type itemResource struct {
-	client *client.Client
+	client        *client.Client
+	defaultLabels map[string]string
+	labelsFormat  string
+	ownerID       string
+	cipher        *tagCipher
+	waiter        *consistencyWaiter
+	exchangeRates *exchangeRates
 }
 
 // itemResourceModel maps the resource schema data.
 type itemResourceModel struct {
+	ID                types.Int64    `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Tag               types.String   `tfsdk:"tag"`
+	Labels            types.Map      `tfsdk:"labels"`
+	LabelsAll         types.Map      `tfsdk:"labels_all"`
+	OwnerID           types.String   `tfsdk:"owner_id"`
+	BaseAmount        types.Number   `tfsdk:"base_amount"`
+	ConflictDetection types.Bool     `tfsdk:"conflict_detection"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// itemIdentityModel maps the resource identity schema data.
+type itemIdentityModel struct {
 	ID   types.Int64  `tfsdk:"id"`
 	Name types.String `tfsdk:"name"`
-	Tag  types.String `tfsdk:"tag"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -47,23 +77,47 @@ func (r *itemResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*inventoryProviderData)
 	if !ok {
 		tflog.Error(ctx, "Unable to prepare client")
 		return
 	}
-	r.client = client
-
+	r.client = data.client
+	r.defaultLabels = data.defaultLabels
+	r.labelsFormat = data.labelsFormat
+	r.ownerID = data.ownerID
+	r.cipher = data.cipher
+	r.waiter = data.waiter
+	r.exchangeRates = data.exchangeRates
 }
 
 // Metadata returns the resource type name.
 func (r *itemResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_item"
+	// Items can be renamed, so the name in the identity follows the item.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *itemResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.Int64Attribute{
+				Description:       "Numeric identifier of the item.",
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the item. When set on import, the item must have this name.",
+				OptionalForImport: true,
+			},
+		},
+	}
 }
 
 // Schema defines the schema for the resource.
-func (r *itemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *itemResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     itemResourceSchemaVersion,
 		Description: "Manage an item.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
@@ -81,26 +135,334 @@ func (r *itemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "The tag for this inventory item.",
 				Optional:    true,
 			},
+			"labels": schema.MapAttribute{
+				Description: "Labels for this inventory item. Labels are encoded into the tag stored by the inventory service.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"labels_all": schema.MapAttribute{
+				Description: "All labels for this inventory item, including the provider default labels.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"owner_id": schema.StringAttribute{
+				Description: "The provider owner ID recorded in the tag for this inventory item.",
+				Computed:    true,
+			},
+			"base_amount": schema.NumberAttribute{
+				Description: "The price held by the tag converted to the base currency of the provider exchange_rates block. " +
+					"Null when no rates are configured, the tag is not a price or there is no rate for its currency.",
+				Computed: true,
+			},
+			"conflict_detection": schema.BoolAttribute{
+				Description: "Fail updates when the item was changed outside of Terraform after the plan was made, instead of overwriting those changes, " +
+					"and warn when a refresh finds changes made outside of Terraform since the last apply.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// ModifyPlan computes the labels and owner that will be encoded into the item
+// tag, and the price in the base currency.
+func (r *itemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compute when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("owner_id"), ownerIDValue(r.ownerID))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tag types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tag"), &tag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("base_amount"), r.exchangeRates.baseAmount(tag))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var labels types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Leave labels_all unknown until every label is known.
+	if labels.IsUnknown() {
+		return
+	}
+	for _, v := range labels.Elements() {
+		if v.IsUnknown() {
+			return
+		}
+	}
+
+	configured := map[string]string{}
+	if !labels.IsNull() {
+		resp.Diagnostics.Append(labels.ElementsAs(ctx, &configured, false)...)
+	}
+
+	labelsAll, diags := types.MapValueFrom(ctx, types.StringType, mergeLabels(r.defaultLabels, configured))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), labelsAll)...)
+}
+
+// encodeTag renders the tag to store for the planned item, including any
+// labels and, for creates, the idempotency key of the create request.
+func (r *itemResource) encodeTag(ctx context.Context, plan itemResourceModel, createKey string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configured := map[string]string{}
+	if !plan.Labels.IsNull() {
+		diags.Append(plan.Labels.ElementsAs(ctx, &configured, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+	}
+
+	tag := itemTag{
+		Tag:       plan.Tag.ValueString(),
+		Labels:    mergeLabels(r.defaultLabels, configured),
+		Owner:     r.ownerID,
+		CreateKey: createKey,
+	}
+
+	encoded, err := tag.encode(r.labelsFormat)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("labels"),
+			"Unable to Encode Item Labels",
+			err.Error(),
+		)
+		return "", diags
+	}
+
+	sealed, err := r.cipher.seal(encoded, plan.Name.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to Encrypt Item Tag",
+			err.Error(),
+		)
+	}
+
+	return sealed, diags
+}
+
+// stateLabels works out which of the stored labels belong in the labels
+// attribute. Labels inherited unchanged from the provider default labels are
+// left out unless they were already tracked in state.
+func (r *itemResource) stateLabels(ctx context.Context, prior types.Map, stored map[string]string) (types.Map, diag.Diagnostics) {
+	tracked := map[string]types.String{}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags := prior.ElementsAs(ctx, &tracked, false)
+		if diags.HasError() {
+			return types.MapNull(types.StringType), diags
+		}
+	}
+
+	labels := map[string]string{}
+	for k, v := range stored {
+		_, isTracked := tracked[k]
+		if defaultValue, isDefault := r.defaultLabels[k]; isTracked || !isDefault || defaultValue != v {
+			labels[k] = v
+		}
+	}
+
+	if len(labels) == 0 && prior.IsNull() {
+		return types.MapNull(types.StringType), nil
+	}
+
+	return types.MapValueFrom(ctx, types.StringType, labels)
+}
+
+// recordLastApplied records the item in private state under each of the keys.
+func (r *itemResource) recordLastApplied(ctx context.Context, private privateStateSetter, item client.Item, keys ...string) diag.Diagnostics {
+	raw, err := openItemTag(r.cipher, item)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic(
+			"Unable to Decrypt Item Tag",
+			fmt.Sprintf("The tag for item %d could not be decrypted: %s", item.Id, err),
+		)}
+	}
+
+	return setLastApplied(ctx, private, lastAppliedItem{Name: item.Name, Tag: raw}, keys...)
+}
+
+// detectConflict re-reads the item before an update and fails when it was
+// changed outside of Terraform since the plan was made.
+func (r *itemResource) detectConflict(ctx context.Context, private privateStateGetter, id int64, timeout time.Duration) diag.Diagnostics {
+	lastObserved, diags := getLastApplied(ctx, private, lastObservedPrivateKey)
+	if diags.HasError() {
+		return diags
+	}
+
+	if lastObserved == nil {
+		tflog.Debug(ctx, "No observed item recorded, skipping conflict detection", map[string]any{"id": id})
+		return diags
+	}
+
+	remote, _, err := findItemForWaiter(ctx, r.client, id)
+	if err != nil {
+		diags.Append(clientErrorDiagnostic("Unable to Read Item", "update", timeout, err))
+		return diags
+	}
+
+	if remote == nil {
+		diags.AddError(
+			"Item Changed Outside of Terraform",
+			fmt.Sprintf("Item %d was deleted outside of Terraform after the plan was made. "+
+				"Refresh the state to plan its recreation.", id),
+		)
+		return diags
+	}
+
+	raw, err := openItemTag(r.cipher, *remote)
+	if err != nil {
+		diags.AddError(
+			"Unable to Decrypt Item Tag",
+			fmt.Sprintf("The tag for item %d could not be decrypted: %s", id, err),
+		)
+		return diags
+	}
+
+	if drift := itemDrift(*lastObserved, lastAppliedItem{Name: remote.Name, Tag: raw}); len(drift) > 0 {
+		diags.AddError(
+			"Item Changed Outside of Terraform",
+			fmt.Sprintf("Item %d was changed outside of Terraform after the plan was made, so it was not updated:\n\n%s\n\n"+
+				"Refresh the state and review the plan to overwrite these changes, or set conflict_detection to false.", id, formatDrift(drift)),
+		)
+	}
+
+	return diags
+}
+
+// tagValue returns the tag as a string value. An empty tag is null, unless the
+// prior value was the empty string.
+func tagValue(prior types.String, tag string) types.String {
+	if tag == "" && (prior.IsNull() || prior.IsUnknown() || prior.ValueString() != "") {
+		return types.StringNull()
+	}
+	return types.StringValue(tag)
+}
+
+// ownerIDValue returns the owner ID as a string value, which is null when the
+// item has no owner.
+func ownerIDValue(ownerID string) types.String {
+	if ownerID == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(ownerID)
+}
+
+// storedLabels returns the decoded labels as a map value, which is never null.
+func storedLabels(ctx context.Context, labels map[string]string) (types.Map, diag.Diagnostics) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return types.MapValueFrom(ctx, types.StringType, labels)
+}
+
 func (r *itemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	// If our ID was a string then we could do this
 	// resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 
-	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if req.ID != "" {
+		ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+		defer cancel()
+
+		id, err := resolveImportID(ctx, r.client, r.cipher, req.ID)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing item",
+				"Could not import item: "+err.Error(),
+			)
+			return
+		}
 
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		return
+	}
+
+	// Imported by an identity = { ... } block.
+	var identity itemIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !identity.Name.IsNull() {
+		resp.Diagnostics.Append(r.checkIdentityName(ctx, identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+}
+
+// checkIdentityName confirms that the item named by an import identity has the
+// name given in the identity.
+func (r *itemResource) checkIdentityName(ctx context.Context, identity itemIdentityModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	item, _, err := findItemForWaiter(ctx, r.client, identity.ID.ValueInt64())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.Append(clientErrorDiagnostic("Unable to Read Item", "read", defaultReadTimeout, err))
+		return diags
+	}
+
+	if item == nil {
+		diags.AddError(
 			"Error importing item",
-			"Could not import item, unexpected error (ID should be an integer): "+err.Error(),
+			fmt.Sprintf("Could not import item %d, it does not exist.", identity.ID.ValueInt64()),
 		)
-		return
+		return diags
+	}
+
+	if item.Name != identity.Name.ValueString() {
+		diags.AddError(
+			"Error importing item",
+			fmt.Sprintf("Could not import item %d, its name is %q but the identity name is %q.",
+				item.Id, item.Name, identity.Name.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// setIdentity records the identity of the item, when Terraform supports
+// resource identity.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, item client.Item) diag.Diagnostics {
+	if identity == nil {
+		return nil
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	return identity.Set(ctx, itemIdentityModel{
+		ID:   types.Int64Value(item.Id),
+		Name: types.StringValue(item.Name),
+	})
 }
 
 // Create a new resource.
@@ -114,8 +476,32 @@ func (r *itemResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	name := plan.Name.ValueString()
-	tag := plan.Tag.ValueString()
+	idempotencyKey, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Item",
+			"Could not generate an idempotency key: "+err.Error(),
+		)
+		return
+	}
+
+	// The idempotency key is also stored in the tag, so an item created by
+	// this attempt can be found again if the outcome is ambiguous.
+	tag, diags := r.encodeTag(ctx, plan, idempotencyKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	item := client.NewItem{
 		Name: name,
@@ -124,28 +510,61 @@ func (r *itemResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Create new item
 
-	itemResponse, err := r.client.AddItem(ctx, item)
+	newItem, ambiguous, err := addItem(ctx, r.client, item, idempotencyKey)
+	recovered := false
+	if err != nil && ambiguous {
+		tflog.Warn(ctx, "Item create failed ambiguously, searching for an item created by this attempt", map[string]any{"error": err.Error()})
+
+		created, searchErr := createdItems(ctx, r.client, r.cipher, item, idempotencyKey)
+		switch {
+		case searchErr != nil:
+			tflog.Warn(ctx, "Unable to search for an item created by this attempt", map[string]any{"error": searchErr.Error()})
+		case len(created) == 1:
+			resp.Diagnostics.AddWarning(
+				"Recovered Item After Failed Create",
+				fmt.Sprintf("Creating the item returned an error, but the inventory service had already created it as item %d. "+
+					"The item has been adopted into state instead of being created again.\n\n"+
+					"Inventory Client Error: %s", created[0].Id, err),
+			)
+			newItem, err, recovered = created[0], nil, true
+		case len(created) > 1:
+			ids := make([]string, 0, len(created))
+			for _, c := range created {
+				ids = append(ids, strconv.FormatInt(c.Id, 10))
+			}
+			resp.Diagnostics.AddError(
+				"Unable to Recover Item After Failed Create",
+				fmt.Sprintf("Creating the item returned an error, and the inventory service created %d items for the request (IDs: %s). "+
+					"Import the item that belongs to this resource and delete the others.\n\n"+
+					"Inventory Client Error: %s", len(created), strings.Join(ids, ", "), err),
+			)
+			return
+		}
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Item",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Create Item", "create", createTimeout, err))
 		return
 	}
 
-	var newItem client.Item
-	if err := json.NewDecoder(itemResponse.Body).Decode(&newItem); err != nil {
+	// Map response body to model
+	storedTag, err := decodeItemTag(r.cipher, newItem)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Invalid format received for Item",
-			err.Error(),
+			"Unable to Decrypt Item Tag",
+			fmt.Sprintf("The tag for item %d could not be decrypted: %s", newItem.Id, err),
 		)
 		return
 	}
-
-	// Map response body to model
 	plan.ID = types.Int64Value(newItem.Id)
 	plan.Name = types.StringValue(newItem.Name)
-	plan.Tag = types.StringValue(*newItem.Tag)
+	plan.Tag = tagValue(plan.Tag, storedTag.Tag)
+	plan.OwnerID = ownerIDValue(storedTag.Owner)
+	plan.BaseAmount = r.exchangeRates.baseAmount(plan.Tag)
+	plan.LabelsAll, diags = storedLabels(ctx, storedTag.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -153,6 +572,22 @@ func (r *itemResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newItem)...)
+	resp.Diagnostics.Append(r.recordLastApplied(ctx, resp.Private, newItem, lastAppliedPrivateKey, lastObservedPrivateKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Wait for the new item to be readable, so the next refresh does not
+	// remove it from state. A recovered item has already been read back.
+	if !recovered {
+		err = r.waiter.wait(ctx, "item to be created", itemExistsCheck(r.client, newItem.Id))
+		if err != nil {
+			resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Confirm Item Creation", "create", createTimeout, err))
+			return
+		}
+	}
 	tflog.Debug(ctx, "Created item resource", map[string]any{"success": true})
 }
 
@@ -167,12 +602,18 @@ func (r *itemResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	itemResponse, err := r.client.FindItemById(ctx, state.ID.ValueInt64())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read Item",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Read Item", "read", readTimeout, err))
 		return
 	}
 
@@ -200,15 +641,62 @@ func (r *itemResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Map response body to model
+	storedTag, err := decodeItemTag(r.cipher, newItem)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Decrypt Item Tag",
+			fmt.Sprintf("The tag for item %d could not be decrypted: %s", newItem.Id, err),
+		)
+		return
+	}
+	labels, diags := r.stateLabels(ctx, state.Labels, storedTag.Labels)
+	resp.Diagnostics.Append(diags...)
+	labelsAll, diags := storedLabels(ctx, storedTag.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	state = itemResourceModel{
-		ID:   types.Int64Value(newItem.Id),
-		Name: types.StringValue(newItem.Name),
-		Tag:  types.StringValue(*newItem.Tag),
+		ID:                types.Int64Value(newItem.Id),
+		Name:              types.StringValue(newItem.Name),
+		Tag:               tagValue(state.Tag, storedTag.Tag),
+		Labels:            labels,
+		LabelsAll:         labelsAll,
+		OwnerID:           ownerIDValue(storedTag.Owner),
+		BaseAmount:        r.exchangeRates.baseAmount(tagValue(state.Tag, storedTag.Tag)),
+		ConflictDetection: state.ConflictDetection,
+		Timeouts:          state.Timeouts,
+	}
+
+	if state.ConflictDetection.ValueBool() {
+		lastApplied, diags := getLastApplied(ctx, req.Private, lastAppliedPrivateKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if lastApplied != nil {
+			raw, _ := openItemTag(r.cipher, newItem)
+			if drift := itemDrift(*lastApplied, lastAppliedItem{Name: newItem.Name, Tag: raw}); len(drift) > 0 {
+				resp.Diagnostics.AddWarning(
+					"Item Changed Outside of Terraform",
+					fmt.Sprintf("Item %d was changed outside of Terraform since it was last applied:\n\n%s", newItem.Id, formatDrift(drift)),
+				)
+			}
+		}
+	}
+
+	// Record what the plan will be based on for conflict detection.
+	resp.Diagnostics.Append(r.recordLastApplied(ctx, resp.Private, newItem, lastObservedPrivateKey)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newItem)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -225,21 +713,38 @@ func (r *itemResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	name := plan.Name.ValueString()
-	tag := plan.Tag.ValueString()
+	tag, diags := r.encodeTag(ctx, plan, "")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	item := client.NewItem{
 		Name: name,
 		Tag:  &tag,
 	}
 
+	if plan.ConflictDetection.ValueBool() {
+		resp.Diagnostics.Append(r.detectConflict(ctx, req.Private, plan.ID.ValueInt64(), updateTimeout)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// update item
 	itemResponse, err := r.client.UpdateItem(ctx, plan.ID.ValueInt64(), item)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Update Item",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Update Item", "update", updateTimeout, err))
 		return
 	}
 
@@ -261,10 +766,30 @@ func (r *itemResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Overwrite items with refreshed state
+	storedTag, err := decodeItemTag(r.cipher, newItem)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Decrypt Item Tag",
+			fmt.Sprintf("The tag for item %d could not be decrypted: %s", newItem.Id, err),
+		)
+		return
+	}
+	labelsAll, diags := storedLabels(ctx, storedTag.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	plan = itemResourceModel{
-		ID:   types.Int64Value(newItem.Id),
-		Name: types.StringValue(newItem.Name),
-		Tag:  types.StringValue(*newItem.Tag),
+		ID:                types.Int64Value(newItem.Id),
+		Name:              types.StringValue(newItem.Name),
+		Tag:               tagValue(plan.Tag, storedTag.Tag),
+		Labels:            plan.Labels,
+		LabelsAll:         labelsAll,
+		OwnerID:           ownerIDValue(storedTag.Owner),
+		BaseAmount:        r.exchangeRates.baseAmount(tagValue(plan.Tag, storedTag.Tag)),
+		ConflictDetection: plan.ConflictDetection,
+		Timeouts:          plan.Timeouts,
 	}
 
 	// Set refreshed state
@@ -273,6 +798,18 @@ func (r *itemResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newItem)...)
+	resp.Diagnostics.Append(r.recordLastApplied(ctx, resp.Private, newItem, lastAppliedPrivateKey, lastObservedPrivateKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = r.waiter.wait(ctx, "item to be updated", itemMatchesCheck(r.client, newItem.Id, item))
+	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Confirm Item Update", "update", updateTimeout, err))
+		return
+	}
 	tflog.Debug(ctx, "Updated item resource", map[string]any{"success": true})
 }
 
@@ -286,13 +823,25 @@ func (r *itemResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// delete item
 	_, err := r.client.DeleteItem(ctx, state.ID.ValueInt64())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Delete Item",
-			err.Error(),
-		)
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Delete Item", "delete", deleteTimeout, err))
+		return
+	}
+
+	err = r.waiter.wait(ctx, "item to be deleted", itemGoneCheck(r.client, state.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Confirm Item Deletion", "delete", deleteTimeout, err))
 		return
 	}
 	tflog.Debug(ctx, "Deleted item resource", map[string]any{"success": true})
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestItemResourceIdentity(t *testing.T) {
+	var mu sync.Mutex
+	tag := func(s string) *string { return &s }
+	items := map[int64]client.Item{
+		7: {Id: 7, Name: "1965 Shelby Cobra", Tag: tag("USD:79,420")},
+	}
+	nextID := int64(8)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var id int64
+		_, _ = fmt.Sscanf(r.URL.Path, "/items/%d", &id)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/items":
+			list := make([]client.Item, 0, len(items))
+			for _, item := range items {
+				list = append(list, item)
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodGet:
+			item, ok := items[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(item)
+		case r.Method == http.MethodPost || r.Method == http.MethodPut:
+			var newItem client.NewItem
+			if err := json.NewDecoder(r.Body).Decode(&newItem); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if r.Method == http.MethodPost {
+				id = nextID
+				nextID++
+			}
+			items[id] = client.Item{Id: id, Name: newItem.Name, Tag: newItem.Tag}
+			_ = json.NewEncoder(w).Encode(items[id])
+		case r.Method == http.MethodDelete:
+			delete(items, id)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	provider, schemas := newTestProviderServer(t, server.URL, nil)
+	resourceType, ok := schemas.ResourceSchemas["inventory_item"].ValueType().(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected resource schema type %s", schemas.ResourceSchemas["inventory_item"].ValueType())
+	}
+	identityType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":   tftypes.Number,
+		"name": tftypes.String,
+	}}
+
+	dynamic := func(t *testing.T, typ tftypes.Type, value tftypes.Value) *tfprotov6.DynamicValue {
+		t.Helper()
+		dv, err := tfprotov6.NewDynamicValue(typ, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dv
+	}
+
+	// config returns a resource value with the given attributes, and every
+	// other attribute null.
+	config := func(attributes map[string]tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for k, typ := range resourceType.AttributeTypes {
+			values[k] = tftypes.NewValue(typ, nil)
+		}
+		for k, v := range attributes {
+			values[k] = v
+		}
+		return tftypes.NewValue(resourceType, values)
+	}
+
+	identity := func(t *testing.T, data *tfprotov6.ResourceIdentityData) map[string]tftypes.Value {
+		t.Helper()
+		if data == nil || data.IdentityData == nil {
+			t.Fatal("expected an identity")
+		}
+		value, err := data.IdentityData.Unmarshal(identityType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			t.Fatal(err)
+		}
+		return attributes
+	}
+
+	checkIdentity := func(t *testing.T, data *tfprotov6.ResourceIdentityData, wantID int64, wantName string) {
+		t.Helper()
+		attributes := identity(t, data)
+		want := map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.Number, wantID),
+			"name": tftypes.NewValue(tftypes.String, wantName),
+		}
+		for k, v := range want {
+			if !attributes[k].Equal(v) {
+				t.Errorf("expected identity %s %s, got %s", k, v, attributes[k])
+			}
+		}
+	}
+
+	checkDiagnostics := func(t *testing.T, diags []*tfprotov6.Diagnostic) {
+		t.Helper()
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityError {
+				t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+			}
+		}
+	}
+
+	// apply plans and applies a change from the prior state to the
+	// configuration.
+	apply := func(t *testing.T, prior tftypes.Value, priorIdentity *tfprotov6.ResourceIdentityData, cfg tftypes.Value) *tfprotov6.ApplyResourceChangeResponse {
+		t.Helper()
+		proposed := cfg
+		if !prior.IsNull() {
+			// Computed attributes keep their prior values.
+			var priorValues, cfgValues map[string]tftypes.Value
+			_ = prior.As(&priorValues)
+			_ = cfg.As(&cfgValues)
+			for k, v := range cfgValues {
+				if v.IsNull() {
+					cfgValues[k] = priorValues[k]
+				}
+			}
+			proposed = tftypes.NewValue(resourceType, cfgValues)
+		}
+
+		planResp, err := provider.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName:         "inventory_item",
+			PriorState:       dynamic(t, resourceType, prior),
+			PriorIdentity:    priorIdentity,
+			ProposedNewState: dynamic(t, resourceType, proposed),
+			Config:           dynamic(t, resourceType, cfg),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkDiagnostics(t, planResp.Diagnostics)
+
+		applyResp, err := provider.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName:        "inventory_item",
+			PriorState:      dynamic(t, resourceType, prior),
+			PlannedState:    planResp.PlannedState,
+			PlannedIdentity: planResp.PlannedIdentity,
+			PlannedPrivate:  planResp.PlannedPrivate,
+			Config:          dynamic(t, resourceType, cfg),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkDiagnostics(t, applyResp.Diagnostics)
+		return applyResp
+	}
+
+	importByIdentity := func(t *testing.T, id int64, name *string) *tfprotov6.ImportResourceStateResponse {
+		t.Helper()
+		var nameValue tftypes.Value
+		if name == nil {
+			nameValue = tftypes.NewValue(tftypes.String, nil)
+		} else {
+			nameValue = tftypes.NewValue(tftypes.String, *name)
+		}
+		resp, err := provider.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+			TypeName: "inventory_item",
+			Identity: &tfprotov6.ResourceIdentityData{
+				IdentityData: dynamic(t, identityType, tftypes.NewValue(identityType, map[string]tftypes.Value{
+					"id":   tftypes.NewValue(tftypes.Number, id),
+					"name": nameValue,
+				})),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	importErrors := func(resp *tfprotov6.ImportResourceStateResponse) string {
+		var details []string
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov6.DiagnosticSeverityError {
+				details = append(details, d.Detail)
+			}
+		}
+		return strings.Join(details, "\n")
+	}
+
+	t.Run("import", func(t *testing.T) {
+		for name, importName := range map[string]*string{
+			"by id":          nil,
+			"by id and name": tag("1965 Shelby Cobra"),
+		} {
+			t.Run(name, func(t *testing.T) {
+				resp := importByIdentity(t, 7, importName)
+				checkDiagnostics(t, resp.Diagnostics)
+				if len(resp.ImportedResources) != 1 {
+					t.Fatalf("expected 1 imported resource, got %d", len(resp.ImportedResources))
+				}
+
+				state, err := resp.ImportedResources[0].State.Unmarshal(resourceType)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var attributes map[string]tftypes.Value
+				if err := state.As(&attributes); err != nil {
+					t.Fatal(err)
+				}
+				if !attributes["id"].Equal(tftypes.NewValue(tftypes.Number, 7)) {
+					t.Errorf("expected id 7, got %s", attributes["id"])
+				}
+			})
+		}
+	})
+
+	t.Run("import name mismatch", func(t *testing.T) {
+		resp := importByIdentity(t, 7, tag("1953 Jaguar C-Type"))
+		want := `Could not import item 7, its name is "1965 Shelby Cobra" but the identity name is "1953 Jaguar C-Type".`
+		if got := importErrors(resp); got != want {
+			t.Errorf("expected error %q, got %q", want, got)
+		}
+	})
+
+	t.Run("import missing item", func(t *testing.T) {
+		resp := importByIdentity(t, 99, tag("1965 Shelby Cobra"))
+		want := "Could not import item 99, it does not exist."
+		if got := importErrors(resp); got != want {
+			t.Errorf("expected error %q, got %q", want, got)
+		}
+	})
+
+	t.Run("create, read and update", func(t *testing.T) {
+		created := apply(t, tftypes.NewValue(resourceType, nil), nil, config(map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "1961 Jaguar E-Type"),
+			"tag":  tftypes.NewValue(tftypes.String, "GBP:61,000"),
+		}))
+		checkIdentity(t, created.NewIdentity, 8, "1961 Jaguar E-Type")
+
+		// The item is renamed outside of Terraform, and the identity
+		// follows it on refresh.
+		mu.Lock()
+		items[8] = client.Item{Id: 8, Name: "1961 Jaguar E-Type Roadster", Tag: items[8].Tag}
+		mu.Unlock()
+
+		readResp, err := provider.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName:        "inventory_item",
+			CurrentState:    created.NewState,
+			CurrentIdentity: created.NewIdentity,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkDiagnostics(t, readResp.Diagnostics)
+		checkIdentity(t, readResp.NewIdentity, 8, "1961 Jaguar E-Type Roadster")
+
+		readState, err := readResp.NewState.Unmarshal(resourceType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updated := apply(t, readState, readResp.NewIdentity, config(map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "1961 Jaguar E-Type Coupe"),
+			"tag":  tftypes.NewValue(tftypes.String, "GBP:61,000"),
+		}))
+		checkIdentity(t, updated.NewIdentity, 8, "1961 Jaguar E-Type Coupe")
+	})
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// restAPIObjectTypeName is the type of the generic REST API provider resource
+// that inventory items can be moved from.
+const restAPIObjectTypeName = "restapi_object"
+
+// restAPIObjectState is the part of the restapi_object state needed to move an
+// item. The data attribute holds the item as a JSON string.
+type restAPIObjectState struct {
+	ID   string `json:"id"`
+	Data string `json:"data"`
+}
+
+// restAPIObjectData is the item encoded in the data attribute of a
+// restapi_object.
+type restAPIObjectData struct {
+	ID   json.Number `json:"id"`
+	Name string      `json:"name"`
+	Tag  *string     `json:"tag"`
+}
+
+// MoveState moves the state of resources from other resource types.
+func (r *itemResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: moveRestAPIObjectState,
+		},
+	}
+}
+
+// moveRestAPIObjectState moves a restapi_object that manages an inventory item
+// from the generic REST API provider.
+func moveRestAPIObjectState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != restAPIObjectTypeName || !strings.HasSuffix(req.SourceProviderAddress, "/restapi") {
+		return
+	}
+
+	tflog.Debug(ctx, "Moving item resource state from "+restAPIObjectTypeName, map[string]any{"source_provider": req.SourceProviderAddress})
+
+	if req.SourceRawState == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Item",
+			"The "+restAPIObjectTypeName+" state is missing.",
+		)
+		return
+	}
+
+	var source restAPIObjectState
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Item",
+			"The "+restAPIObjectTypeName+" state could not be read: "+err.Error(),
+		)
+		return
+	}
+
+	var data restAPIObjectData
+	decoder := json.NewDecoder(strings.NewReader(source.Data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Item",
+			"The data attribute of the "+restAPIObjectTypeName+" is not a JSON item: "+err.Error(),
+		)
+		return
+	}
+
+	rawID := source.ID
+	if rawID == "" {
+		rawID = data.ID.String()
+	}
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Item",
+			fmt.Sprintf("The %s ID %q is not an inventory item ID: %s", restAPIObjectTypeName, rawID, err),
+		)
+		return
+	}
+
+	if data.Name == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Move Item",
+			"The data attribute of the "+restAPIObjectTypeName+" has no item name.",
+		)
+		return
+	}
+
+	var storedTag itemTag
+	if data.Tag != nil {
+		storedTag = parseItemTag(*data.Tag)
+	}
+	labelsAll, diags := storedLabels(ctx, storedTag.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The timeouts block is left null.
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("id"), types.Int64Value(id))...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("name"), types.StringValue(data.Name))...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("tag"), tagValue(types.StringNull(), storedTag.Tag))...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("labels"), types.MapNull(types.StringType))...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("labels_all"), labelsAll)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("owner_id"), ownerIDValue(storedTag.Owner))...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("conflict_detection"), types.BoolNull())...)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestItemResourceMoveState(t *testing.T) {
+	tests := map[string]struct {
+		sourceTypeName string
+		state          string
+		want           map[string]tftypes.Value
+		wantErr        bool
+	}{
+		"restapi_object": {
+			sourceTypeName: "restapi_object",
+			state:          `{"id":"1000","path":"/items","data":"{\"id\":1000,\"name\":\"1953 Jaguar C-Type\",\"tag\":\"USD:79,420|labels:env=prod|owner:ws-1\"}","api_data":{"name":"1953 Jaguar C-Type"}}`,
+			want: map[string]tftypes.Value{
+				"id":       tftypes.NewValue(tftypes.Number, 1000),
+				"name":     tftypes.NewValue(tftypes.String, "1953 Jaguar C-Type"),
+				"tag":      tftypes.NewValue(tftypes.String, "USD:79,420"),
+				"owner_id": tftypes.NewValue(tftypes.String, "ws-1"),
+			},
+		},
+		"id in data": {
+			sourceTypeName: "restapi_object",
+			state:          `{"path":"/items","data":"{\"id\":1001,\"name\":\"1965 Shelby Cobra\"}"}`,
+			want: map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.Number, 1001),
+				"name": tftypes.NewValue(tftypes.String, "1965 Shelby Cobra"),
+				"tag":  tftypes.NewValue(tftypes.String, nil),
+			},
+		},
+		"invalid data": {
+			sourceTypeName: "restapi_object",
+			state:          `{"id":"1000","data":"not json"}`,
+			wantErr:        true,
+		},
+		"other resource": {
+			sourceTypeName: "restapi_other",
+			state:          `{"id":"1000"}`,
+			wantErr:        true,
+		},
+	}
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateType := schemaResp.ResourceSchemas["inventory_item"].ValueType()
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/mastercard/restapi",
+				SourceTypeName:        tt.sourceTypeName,
+				SourceState:           &tfprotov6.RawState{JSON: []byte(tt.state)},
+				TargetTypeName:        "inventory_item",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantErr {
+				if len(resp.Diagnostics) == 0 {
+					t.Error("expected an error diagnostic")
+				}
+				return
+			}
+			for _, d := range resp.Diagnostics {
+				t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+			if resp.TargetState == nil {
+				t.Fatal("expected target state")
+			}
+
+			moved, err := resp.TargetState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var attributes map[string]tftypes.Value
+			if err := moved.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+
+			for k, want := range tt.want {
+				if !attributes[k].Equal(want) {
+					t.Errorf("expected %s %s, got %s", k, want, attributes[k])
+				}
+			}
+		})
+	}
+}
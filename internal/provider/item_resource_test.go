@@ -46,6 +46,40 @@ resource "inventory_item" "test" {
 					resource.TestCheckResourceAttrSet("inventory_item.test", "id"),
 				),
 			},
+			// Timeouts testing
+			{
+				Config: providerConfig + `
+resource "inventory_item" "test" {
+    name = "1928 de Havilland DH-60GM"
+    tag  = "USD:110,781"
+
+    timeouts {
+        update = "30s"
+    }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("inventory_item.test", "timeouts.update", "30s"),
+				),
+			},
+			// Labels testing
+			{
+				Config: providerConfig + `
+resource "inventory_item" "test" {
+    name = "1928 de Havilland DH-60GM"
+    tag  = "USD:110,781"
+    labels = {
+        owner = "hangar-2"
+    }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("inventory_item.test", "tag", "USD:110,781"),
+					resource.TestCheckResourceAttr("inventory_item.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("inventory_item.test", "labels.owner", "hangar-2"),
+					resource.TestCheckResourceAttr("inventory_item.test", "labels_all.owner", "hangar-2"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
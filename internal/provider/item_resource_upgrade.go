@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// itemResourceSchemaVersion is the version of the inventory_item schema.
+//
+// Version 1 stores an item written without a tag with a null tag instead of an
+// empty string. Empty tags in version 0 state are kept as they are.
+const itemResourceSchemaVersion = 1
+
+// UpgradeState upgrades state written by earlier versions of the schema.
+func (r *itemResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   itemResourceSchemaV0(ctx),
+			StateUpgrader: upgradeItemResourceStateV0,
+		},
+	}
+}
+
+// itemResourceSchemaV0 is version 0 of the inventory_item schema. Attributes
+// missing from older state, such as labels, are read as null.
+func itemResourceSchemaV0(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"tag": schema.StringAttribute{
+				Optional: true,
+			},
+			"labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"labels_all": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"owner_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"conflict_detection": schema.BoolAttribute{
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// itemResourceModelV0 maps the version 0 resource schema data.
+type itemResourceModelV0 struct {
+	ID                types.Int64    `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Tag               types.String   `tfsdk:"tag"`
+	Labels            types.Map      `tfsdk:"labels"`
+	LabelsAll         types.Map      `tfsdk:"labels_all"`
+	OwnerID           types.String   `tfsdk:"owner_id"`
+	ConflictDetection types.Bool     `tfsdk:"conflict_detection"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// upgradeItemResourceStateV0 upgrades version 0 state. Values are carried over
+// as they are, so an empty tag stays the empty string.
+func upgradeItemResourceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	tflog.Debug(ctx, "Upgrading item resource state from version 0")
+	var prior itemResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The base amount is computed on the next refresh.
+	state := itemResourceModel{
+		ID:                prior.ID,
+		Name:              prior.Name,
+		Tag:               prior.Tag,
+		Labels:            prior.Labels,
+		LabelsAll:         prior.LabelsAll,
+		OwnerID:           prior.OwnerID,
+		BaseAmount:        types.NumberNull(),
+		ConflictDetection: prior.ConflictDetection,
+		Timeouts:          prior.Timeouts,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestItemResourceUpgradeStateV0(t *testing.T) {
+	tests := map[string]struct {
+		state   string
+		wantTag tftypes.Value
+	}{
+		"tag": {
+			state:   `{"id":1000,"name":"1953 Jaguar C-Type","tag":"USD:79,420","labels":{"env":"prod"},"labels_all":{"env":"prod"},"owner_id":"ws-1","conflict_detection":true,"timeouts":null}`,
+			wantTag: tftypes.NewValue(tftypes.String, "USD:79,420"),
+		},
+		"empty tag": {
+			state:   `{"id":1000,"name":"1953 Jaguar C-Type","tag":"","labels":null,"labels_all":{},"owner_id":null,"conflict_detection":null,"timeouts":null}`,
+			wantTag: tftypes.NewValue(tftypes.String, ""),
+		},
+		"before labels": {
+			state:   `{"id":1000,"name":"1953 Jaguar C-Type","tag":""}`,
+			wantTag: tftypes.NewValue(tftypes.String, ""),
+		},
+	}
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateType := schemaResp.ResourceSchemas["inventory_item"].ValueType()
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: "inventory_item",
+				Version:  0,
+				RawState: &tfprotov6.RawState{JSON: []byte(tt.state)},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+			if resp.UpgradedState == nil {
+				t.Fatal("expected upgraded state")
+			}
+
+			upgraded, err := resp.UpgradedState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var attributes map[string]tftypes.Value
+			if err := upgraded.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+
+			if !attributes["tag"].Equal(tt.wantTag) {
+				t.Errorf("expected tag %s, got %s", tt.wantTag, attributes["tag"])
+			}
+
+			var prior map[string]any
+			if err := json.Unmarshal([]byte(tt.state), &prior); err != nil {
+				t.Fatal(err)
+			}
+			if !attributes["name"].Equal(tftypes.NewValue(tftypes.String, prior["name"])) {
+				t.Errorf("expected name %v, got %s", prior["name"], attributes["name"])
+			}
+			if !attributes["id"].Equal(tftypes.NewValue(tftypes.Number, prior["id"])) {
+				t.Errorf("expected id %v, got %s", prior["id"], attributes["id"])
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+// The inventory service only stores a single free-form tag for each item, so
+// the provider packs any additional metadata into that string by appending
+// reserved segments after the practitioner supplied tag:
+//
+//	<tag>|labels:<encoded labels>|owner:<owner id>|create:<idempotency key>
+//
+// Labels are encoded either as key=value pairs (the default), using URL query
+// encoding with the keys sorted, or as a JSON object with the keys sorted:
+//
+//	USD:79,420|labels:env=prod&owner=alice
+//	USD:79,420|labels:{"env":"prod","owner":"alice"}
+//
+// The owner segment records the provider owner_id of the Terraform
+// configuration that manages the item, using URL query escaping.
+//
+// The create segment records the idempotency key of the request that created
+// the item, so the item can be found again if that request fails ambiguously.
+// It is dropped the next time the item is updated.
+//
+// Segments are parsed from the end of the stored tag, so the practitioner
+// supplied tag may itself contain the separator. A stored tag that does not
+// end in well-formed reserved segments is treated as a plain tag.
+const (
+	tagSegmentSeparator = "|"
+	labelsSegmentPrefix = "labels:"
+	ownerSegmentPrefix  = "owner:"
+	createSegmentPrefix = "create:"
+
+	labelsFormatKeyValue = "kv"
+	labelsFormatJSON     = "json"
+)
+
+// itemTag is the decoded form of the tag stored by the inventory service.
+type itemTag struct {
+	Tag       string
+	Labels    map[string]string
+	Owner     string
+	CreateKey string
+}
+
+// parseItemTag splits a stored tag into the practitioner supplied tag and any
+// reserved metadata segments.
+func parseItemTag(raw string) itemTag {
+	parsed := itemTag{Tag: raw}
+
+	// Segments are written in a fixed order, so walk them back to front.
+	prefixes := []string{createSegmentPrefix, ownerSegmentPrefix, labelsSegmentPrefix}
+	for _, prefix := range prefixes {
+		idx := strings.LastIndex(parsed.Tag, tagSegmentSeparator)
+		if idx < 0 {
+			break
+		}
+
+		segment := parsed.Tag[idx+len(tagSegmentSeparator):]
+		if !strings.HasPrefix(segment, prefix) {
+			continue
+		}
+		payload := strings.TrimPrefix(segment, prefix)
+
+		switch prefix {
+		case createSegmentPrefix:
+			key, err := url.QueryUnescape(payload)
+			if err != nil || key == "" {
+				return parsed
+			}
+			parsed.CreateKey = key
+		case ownerSegmentPrefix:
+			owner, err := url.QueryUnescape(payload)
+			if err != nil || owner == "" {
+				return parsed
+			}
+			parsed.Owner = owner
+		case labelsSegmentPrefix:
+			labels, err := decodeLabels(payload)
+			if err != nil {
+				return parsed
+			}
+			parsed.Labels = labels
+		}
+
+		parsed.Tag = parsed.Tag[:idx]
+	}
+
+	return parsed
+}
+
+// openItemTag decrypts the tag of an item returned by the inventory service.
+func openItemTag(c *tagCipher, item client.Item) (string, error) {
+	if item.Tag == nil {
+		return "", nil
+	}
+	return c.open(*item.Tag, item.Name)
+}
+
+// decodeItemTag decrypts and parses the tag of an item returned by the
+// inventory service.
+func decodeItemTag(c *tagCipher, item client.Item) (itemTag, error) {
+	raw, err := openItemTag(c, item)
+	if err != nil {
+		return itemTag{}, err
+	}
+
+	return parseItemTag(raw), nil
+}
+
+// encode renders the tag as it should be stored by the inventory service.
+func (t itemTag) encode(labelsFormat string) (string, error) {
+	encoded := t.Tag
+
+	if len(t.Labels) > 0 {
+		labels, err := encodeLabels(t.Labels, labelsFormat)
+		if err != nil {
+			return "", err
+		}
+		encoded += tagSegmentSeparator + labelsSegmentPrefix + labels
+	}
+
+	if t.Owner != "" {
+		encoded += tagSegmentSeparator + ownerSegmentPrefix + url.QueryEscape(t.Owner)
+	}
+
+	if t.CreateKey != "" {
+		encoded += tagSegmentSeparator + createSegmentPrefix + url.QueryEscape(t.CreateKey)
+	}
+
+	return encoded, nil
+}
+
+// encodeLabels serializes labels in the requested format. Both formats are
+// stable, so the same labels always produce the same tag.
+func encodeLabels(labels map[string]string, format string) (string, error) {
+	switch format {
+	case labelsFormatKeyValue, "":
+		values := url.Values{}
+		for k, v := range labels {
+			values.Set(k, v)
+		}
+		return values.Encode(), nil
+	case labelsFormatJSON:
+		// encoding/json sorts map keys, which keeps the output stable.
+		b, err := json.Marshal(labels)
+		if err != nil {
+			return "", err
+		}
+		// Escape the segment separator so it can never appear in the payload.
+		return strings.ReplaceAll(string(b), tagSegmentSeparator, `\u007c`), nil
+	default:
+		return "", fmt.Errorf("unsupported labels format %q", format)
+	}
+}
+
+// decodeLabels parses labels written in either supported format.
+func decodeLabels(encoded string) (map[string]string, error) {
+	labels := map[string]string{}
+
+	if strings.HasPrefix(encoded, "{") {
+		if err := json.Unmarshal([]byte(encoded), &labels); err != nil {
+			return nil, err
+		}
+		return labels, nil
+	}
+
+	values, err := url.ParseQuery(encoded)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range values {
+		if len(v) != 1 {
+			return nil, fmt.Errorf("label %q is set more than once", k)
+		}
+		labels[k] = v[0]
+	}
+
+	return labels, nil
+}
+
+// mergeLabels returns the provider default labels overlaid with the resource
+// labels.
+func mergeLabels(defaults, labels map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(labels))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemTagEncode(t *testing.T) {
+	tag := itemTag{
+		Tag: "USD:79,420",
+		Labels: map[string]string{
+			"owner": "alice",
+			"env":   "prod|eu",
+		},
+		Owner:     "team-a/prod",
+		CreateKey: "key-1",
+	}
+
+	tests := map[string]string{
+		labelsFormatKeyValue: "USD:79,420|labels:env=prod%7Ceu&owner=alice|owner:team-a%2Fprod|create:key-1",
+		labelsFormatJSON:     `USD:79,420|labels:{"env":"prod\u007ceu","owner":"alice"}|owner:team-a%2Fprod|create:key-1`,
+	}
+
+	for format, expected := range tests {
+		encoded, err := tag.encode(format)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", format, err)
+		}
+		if encoded != expected {
+			t.Errorf("%s: expected %q, got %q", format, expected, encoded)
+		}
+		if parsed := parseItemTag(encoded); !reflect.DeepEqual(parsed, tag) {
+			t.Errorf("%s: expected round trip to %#v, got %#v", format, tag, parsed)
+		}
+	}
+}
+
+func TestParseItemTag(t *testing.T) {
+	tests := map[string]itemTag{
+		"":                         {Tag: ""},
+		"USD:2.99":                 {Tag: "USD:2.99"},
+		"a|b":                      {Tag: "a|b"},
+		"a|b|labels:env=prod":      {Tag: "a|b", Labels: map[string]string{"env": "prod"}},
+		"|labels:{\"env\":\"qa\"}": {Tag: "", Labels: map[string]string{"env": "qa"}},
+		"USD:2.99|labels:{broken":  {Tag: "USD:2.99|labels:{broken"},
+		"USD:2.99|owner:ws-1":      {Tag: "USD:2.99", Owner: "ws-1"},
+		"x|labels:a=b|owner:ws-1":  {Tag: "x", Labels: map[string]string{"a": "b"}, Owner: "ws-1"},
+		"x|owner:ws-1|labels:a=b":  {Tag: "x|owner:ws-1", Labels: map[string]string{"a": "b"}},
+		"x|owner:ws-1|create:k-1":  {Tag: "x", Owner: "ws-1", CreateKey: "k-1"},
+		"x|create:k-1|owner:ws-1":  {Tag: "x|create:k-1", Owner: "ws-1"},
+		"x|create:":                {Tag: "x|create:"},
+	}
+
+	for raw, expected := range tests {
+		if parsed := parseItemTag(raw); !reflect.DeepEqual(parsed, expected) {
+			t.Errorf("%q: expected %#v, got %#v", raw, expected, parsed)
+		}
+	}
+}
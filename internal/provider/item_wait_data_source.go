@@ -0,0 +1,418 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Defaults for the inventory_item_wait data source.
+const (
+	defaultItemWaitInterval    = 2 * time.Second
+	defaultItemWaitMaxInterval = 30 * time.Second
+	defaultItemWaitTimeout     = 5 * time.Minute
+	defaultItemWaitBackoff     = 2
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &itemWaitDataSource{}
+	_ datasource.DataSourceWithConfigure      = &itemWaitDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &itemWaitDataSource{}
+)
+
+// NewItemWaitDataSource is a helper function to simplify the provider implementation.
+func NewItemWaitDataSource() datasource.DataSource {
+	return &itemWaitDataSource{}
+}
+
+// itemWaitDataSource is the data source implementation.
+type itemWaitDataSource struct {
+	client *client.Client
+	cipher *tagCipher
+}
+
+// itemWaitDataSourceModel maps the data source schema data.
+type itemWaitDataSourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	TagRegex    types.String `tfsdk:"tag_regex"`
+	TagEquals   types.String `tfsdk:"tag_equals"`
+	Interval    types.String `tfsdk:"interval"`
+	MaxInterval types.String `tfsdk:"max_interval"`
+	Backoff     types.Number `tfsdk:"backoff"`
+	Timeout     types.String `tfsdk:"timeout"`
+	Tag         types.String `tfsdk:"tag"`
+	Labels      types.Map    `tfsdk:"labels"`
+	Attempts    types.Int64  `tfsdk:"attempts"`
+}
+
+// itemWaitCondition is the awaited state of an item.
+type itemWaitCondition struct {
+	tagRegex  *regexp.Regexp
+	tagEquals *string
+}
+
+// matches reports whether a decoded tag satisfies the condition.
+func (c itemWaitCondition) matches(storedTag itemTag) bool {
+	if c.tagRegex != nil && !c.tagRegex.MatchString(storedTag.Tag) {
+		return false
+	}
+	if c.tagEquals != nil && storedTag.Tag != *c.tagEquals {
+		return false
+	}
+	return true
+}
+
+// itemWaitSchedule controls how often the item is polled.
+type itemWaitSchedule struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	backoff     float64
+	timeout     time.Duration
+}
+
+// next returns the interval to wait after the given interval. The interval is
+// capped before converting it to a duration, so that a large backoff cannot
+// overflow into a negative duration.
+func (s itemWaitSchedule) next(interval time.Duration) time.Duration {
+	next := float64(interval) * s.backoff
+	if next >= float64(s.maxInterval) {
+		return s.maxInterval
+	}
+	if d := time.Duration(next); d > 0 {
+		return d
+	}
+	return s.maxInterval
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *itemWaitDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	d.client = data.client
+	d.cipher = data.cipher
+}
+
+// Metadata returns the data source type name.
+func (d *itemWaitDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item_wait"
+}
+
+// Schema defines the schema for the data source.
+func (d *itemWaitDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Wait until an item exists, and optionally until its tag matches a condition, such as for items created by another pipeline. " +
+			"The item is polled with exponential backoff until it is found or the timeout expires, retrying server errors and dropped connections.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Identifier of the item to wait for. Exactly one of id or name must be set, and it is computed when name is set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the item to wait for. When several items have the name, the one with the lowest identifier that satisfies the tag condition is used. " +
+					"Computed when id is set.",
+				Optional: true,
+				Computed: true,
+			},
+			"tag_regex": schema.StringAttribute{
+				Description: "Also wait until the tag, not including labels or the owner ID, matches this regular expression.",
+				Optional:    true,
+			},
+			"tag_equals": schema.StringAttribute{
+				Description: "Also wait until the tag, not including labels or the owner ID, is exactly this value.",
+				Optional:    true,
+			},
+			"interval": schema.StringAttribute{
+				Description: "How long to wait after the first attempt, such as \"500ms\" or \"2s\". Defaults to \"2s\".",
+				Optional:    true,
+			},
+			"max_interval": schema.StringAttribute{
+				Description: "The longest wait between attempts. Defaults to \"30s\".",
+				Optional:    true,
+			},
+			"backoff": schema.NumberAttribute{
+				Description: "The factor each wait is multiplied by, up to max_interval. 1 polls at a fixed interval. Defaults to 2.",
+				Optional:    true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "How long to wait in total, such as \"10m\". Defaults to \"5m\".",
+				Optional:    true,
+			},
+			"tag": schema.StringAttribute{
+				Description: "The tag for the item.",
+				Computed:    true,
+			},
+			"labels": schema.MapAttribute{
+				Description: "The labels decoded from the tag for the item.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"attempts": schema.Int64Attribute{
+				Description: "How many times the item was polled.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the data source configuration.
+func (d *itemWaitDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config itemWaitDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.ID.IsUnknown() && !config.Name.IsUnknown() && config.ID.IsNull() == config.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Item Wait",
+			"Exactly one of id or name must be set.",
+		)
+	}
+
+	_, diags := compileRegexAttribute(config.TagRegex, path.Root("tag_regex"))
+	resp.Diagnostics.Append(diags...)
+
+	_, diags = itemWaitScheduleFromModel(config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// itemWaitScheduleFromModel reads the polling schedule, skipping unknown
+// values.
+func itemWaitScheduleFromModel(config itemWaitDataSourceModel) (itemWaitSchedule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	s := itemWaitSchedule{
+		interval:    defaultItemWaitInterval,
+		maxInterval: defaultItemWaitMaxInterval,
+		backoff:     defaultItemWaitBackoff,
+		timeout:     defaultItemWaitTimeout,
+	}
+
+	for _, attr := range []struct {
+		name  string
+		value types.String
+		dest  *time.Duration
+	}{
+		{"interval", config.Interval, &s.interval},
+		{"max_interval", config.MaxInterval, &s.maxInterval},
+		{"timeout", config.Timeout, &s.timeout},
+	} {
+		if attr.value.IsNull() || attr.value.IsUnknown() {
+			continue
+		}
+		duration, err := time.ParseDuration(attr.value.ValueString())
+		if err != nil || duration <= 0 {
+			diags.AddAttributeError(
+				path.Root(attr.name),
+				"Invalid Duration",
+				fmt.Sprintf("The %s must be a positive duration such as \"2s\", got %q.", attr.name, attr.value.ValueString()),
+			)
+			continue
+		}
+		*attr.dest = duration
+	}
+
+	if !config.Backoff.IsNull() && !config.Backoff.IsUnknown() {
+		backoff, _ := config.Backoff.ValueBigFloat().Float64()
+		if backoff < 1 {
+			diags.AddAttributeError(
+				path.Root("backoff"),
+				"Invalid Backoff",
+				fmt.Sprintf("The backoff must be at least 1, got %s.", config.Backoff.ValueBigFloat().Text('g', -1)),
+			)
+		} else {
+			s.backoff = backoff
+		}
+	}
+
+	return s, diags
+}
+
+// Read waits for the item and refreshes the Terraform state with it.
+func (d *itemWaitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read item wait data source")
+	var state itemWaitDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, diags := itemWaitScheduleFromModel(state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var condition itemWaitCondition
+	condition.tagRegex, diags = compileRegexAttribute(state.TagRegex, path.Root("tag_regex"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !state.TagEquals.IsNull() {
+		tag := state.TagEquals.ValueString()
+		condition.tagEquals = &tag
+	}
+
+	poll := func(ctx context.Context) (*client.Item, itemTag, string, error) {
+		return d.findByID(ctx, state.ID.ValueInt64(), condition)
+	}
+	if !state.Name.IsNull() {
+		poll = func(ctx context.Context) (*client.Item, itemTag, string, error) {
+			return d.findByName(ctx, state.Name.ValueString(), condition)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, schedule.timeout)
+	defer cancel()
+
+	start := time.Now()
+	interval := schedule.interval
+	var observed string
+
+	for attempt := 1; ; attempt++ {
+		item, storedTag, o, err := poll(ctx)
+		if o != "" {
+			observed = o
+		}
+		// Server errors and dropped connections are retried until the timeout.
+		if isTransientError(err) {
+			observed = err.Error()
+		} else if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			resp.Diagnostics.Append(clientErrorDiagnostic("Unable to Read Item", "read", schedule.timeout, err))
+			return
+		}
+
+		tflog.Info(ctx, "Waiting for item", map[string]any{
+			"attempt":  attempt,
+			"observed": observed,
+			"elapsed":  time.Since(start).String(),
+		})
+
+		if item != nil {
+			labels, diags := storedLabels(ctx, storedTag.Labels)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			state.ID = types.Int64Value(item.Id)
+			state.Name = types.StringValue(item.Name)
+			state.Tag = types.StringValue(storedTag.Tag)
+			state.Labels = labels
+			state.Attempts = types.Int64Value(int64(attempt))
+
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			tflog.Debug(ctx, "Finished reading item wait data source", map[string]any{"success": true, "attempts": attempt})
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+			interval = schedule.next(interval)
+			continue
+		}
+
+		if observed == "" {
+			observed = "nothing, as no attempt completed"
+		}
+		resp.Diagnostics.AddError(
+			"Timed Out Waiting for Item",
+			fmt.Sprintf("The item was not found within the timeout of %s, after %d attempts. The timeout can be raised with the timeout attribute.\n\n"+
+				"Last observed: %s", schedule.timeout, attempt, observed),
+		)
+		return
+	}
+}
+
+// findByID reads an item by identifier. It returns the item only when it
+// satisfies the condition, along with a description of what was observed.
+func (d *itemWaitDataSource) findByID(ctx context.Context, id int64, condition itemWaitCondition) (*client.Item, itemTag, string, error) {
+	item, _, err := findItemForWaiter(ctx, d.client, id)
+	if err != nil {
+		return nil, itemTag{}, "", err
+	}
+	if item == nil {
+		return nil, itemTag{}, fmt.Sprintf("item %d does not exist", id), nil
+	}
+
+	storedTag, err := decodeItemTag(d.cipher, *item)
+	if err != nil {
+		return nil, itemTag{}, fmt.Sprintf("item %d has a tag that could not be decrypted: %s", id, err), nil
+	}
+	observed := fmt.Sprintf("item %d (%s) has tag %q", id, item.Name, storedTag.Tag)
+	if !condition.matches(storedTag) {
+		return nil, itemTag{}, observed, nil
+	}
+	return item, storedTag, observed, nil
+}
+
+// findByName searches for an item by name. It returns the item with the
+// lowest identifier that satisfies the condition, along with a description of
+// what was observed.
+func (d *itemWaitDataSource) findByName(ctx context.Context, name string, condition itemWaitCondition) (*client.Item, itemTag, string, error) {
+	items, err := findItems(ctx, d.client, client.FindItemsParams{})
+	if err != nil {
+		return nil, itemTag{}, "", err
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Id < items[j].Id
+	})
+
+	var seen []string
+	for i, item := range items {
+		if item.Name != name {
+			continue
+		}
+
+		storedTag, err := decodeItemTag(d.cipher, item)
+		if err != nil {
+			seen = append(seen, fmt.Sprintf("item %d has a tag that could not be decrypted", item.Id))
+			continue
+		}
+		if condition.matches(storedTag) {
+			return &items[i], storedTag, fmt.Sprintf("item %d has tag %q", item.Id, storedTag.Tag), nil
+		}
+		seen = append(seen, fmt.Sprintf("item %d has tag %q", item.Id, storedTag.Tag))
+	}
+
+	if len(seen) == 0 {
+		return nil, itemTag{}, fmt.Sprintf("no item is named %q", name), nil
+	}
+	return nil, itemTag{}, fmt.Sprintf("items named %q: %s", name, joinObserved(seen)), nil
+}
+
+// joinObserved lists observations, eliding all but the first few.
+func joinObserved(seen []string) string {
+	const limit = 5
+	if len(seen) <= limit {
+		return strings.Join(seen, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(seen[:limit], "; "), len(seen)-limit)
+}
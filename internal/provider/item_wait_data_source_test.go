@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestItemWaitDataSource(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+
+	// Item 1 appears on the third request and is priced on the fifth.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		mu.Unlock()
+
+		tag := "pending"
+		if n >= 5 {
+			tag = "USD:79,420|labels:env=prod"
+		}
+		item := client.Item{Id: 1, Name: "1965 Shelby Cobra", Tag: &tag}
+
+		switch {
+		case r.URL.Path == "/items":
+			items := []client.Item{{Id: 2, Name: "1953 Jaguar C-Type"}}
+			if n >= 3 {
+				items = append(items, item)
+			}
+			_ = json.NewEncoder(w).Encode(items)
+		case r.URL.Path == "/items/1" && n >= 3:
+			_ = json.NewEncoder(w).Encode(item)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := context.Background()
+	d := &itemWaitDataSource{client: c}
+
+	read := func(t *testing.T, config map[string]tftypes.Value) datasource.ReadResponse {
+		t.Helper()
+		mu.Lock()
+		requests = 0
+		mu.Unlock()
+
+		config["interval"] = tftypes.NewValue(tftypes.String, "1ms")
+		config["max_interval"] = tftypes.NewValue(tftypes.String, "4ms")
+		return readDataSource(t, d, config)
+	}
+
+	tests := map[string]struct {
+		config       map[string]tftypes.Value
+		wantAttempts int64
+	}{
+		"id exists": {
+			config:       map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.Number, 1)},
+			wantAttempts: 3,
+		},
+		"id tag": {
+			config: map[string]tftypes.Value{
+				"id":        tftypes.NewValue(tftypes.Number, 1),
+				"tag_regex": tftypes.NewValue(tftypes.String, "^USD:"),
+			},
+			wantAttempts: 5,
+		},
+		"name tag": {
+			config: map[string]tftypes.Value{
+				"name":       tftypes.NewValue(tftypes.String, "1965 Shelby Cobra"),
+				"tag_equals": tftypes.NewValue(tftypes.String, "USD:79,420"),
+			},
+			wantAttempts: 5,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := read(t, tt.config)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var state itemWaitDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if state.ID.ValueInt64() != 1 || state.Name.ValueString() != "1965 Shelby Cobra" {
+				t.Errorf("expected item 1, got %s %s", state.ID, state.Name)
+			}
+			if state.Attempts.ValueInt64() != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %s", tt.wantAttempts, state.Attempts)
+			}
+		})
+	}
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+		resp := read(t, map[string]tftypes.Value{
+			"id":         tftypes.NewValue(tftypes.Number, 1),
+			"tag_equals": tftypes.NewValue(tftypes.String, "GBP:61,000"),
+			"timeout":    tftypes.NewValue(tftypes.String, "50ms"),
+		})
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error")
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Errorf("expected the wait to stop at the timeout, took %s", time.Since(start))
+		}
+
+		detail := resp.Diagnostics.Errors()[0].Detail()
+		if !strings.Contains(detail, `Last observed: item 1 (1965 Shelby Cobra) has tag "USD:79,420"`) {
+			t.Errorf("expected the last observed tag in the error, got %q", detail)
+		}
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		// Server errors are retried until the item is found.
+		failures := 0
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			if failures < 2 {
+				failures++
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			tag := "USD:79,420"
+			_ = json.NewEncoder(w).Encode(client.Item{Id: 1, Name: "1965 Shelby Cobra", Tag: &tag})
+		}))
+		defer failing.Close()
+
+		fc, err := client.NewClient(failing.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp := readDataSource(t, &itemWaitDataSource{client: fc}, map[string]tftypes.Value{
+			"id":       tftypes.NewValue(tftypes.Number, 1),
+			"interval": tftypes.NewValue(tftypes.String, "1ms"),
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var state itemWaitDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if state.Attempts.ValueInt64() != 3 {
+			t.Errorf("expected 3 attempts, got %s", state.Attempts)
+		}
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		// A regular expression that was unknown during validation is only
+		// checked when reading.
+		resp := read(t, map[string]tftypes.Value{
+			"id":        tftypes.NewValue(tftypes.Number, 1),
+			"tag_regex": tftypes.NewValue(tftypes.String, "("),
+		})
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Invalid Regular Expression" {
+			t.Errorf("expected an invalid regular expression error, got %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestItemWaitScheduleNext(t *testing.T) {
+	s := itemWaitSchedule{maxInterval: 30 * time.Second, backoff: 2}
+	if got := s.next(2 * time.Second); got != 4*time.Second {
+		t.Errorf("expected 4s, got %s", got)
+	}
+	if got := s.next(20 * time.Second); got != 30*time.Second {
+		t.Errorf("expected the max interval, got %s", got)
+	}
+
+	// Intervals that would overflow a duration are capped.
+	for _, backoff := range []float64{1e12, 1e300} {
+		s.backoff = backoff
+		if got := s.next(time.Hour); got != 30*time.Second {
+			t.Errorf("backoff %g: expected the max interval, got %s", backoff, got)
+		}
+	}
+}
+
+func TestItemWaitDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := &itemWaitDataSource{}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	schemaType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected schema type %s", schemaResp.Schema.Type())
+	}
+
+	tests := map[string]struct {
+		config  map[string]tftypes.Value
+		wantErr bool
+	}{
+		"id":       {config: map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.Number, 1)}},
+		"neither":  {config: map[string]tftypes.Value{}, wantErr: true},
+		"both":     {config: map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.Number, 1), "name": tftypes.NewValue(tftypes.String, "x")}, wantErr: true},
+		"regex":    {config: map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.Number, 1), "tag_regex": tftypes.NewValue(tftypes.String, "(")}, wantErr: true},
+		"interval": {config: map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.Number, 1), "interval": tftypes.NewValue(tftypes.String, "soon")}, wantErr: true},
+		"backoff":  {config: map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.Number, 1), "backoff": tftypes.NewValue(tftypes.Number, 0.5)}, wantErr: true},
+		"unknown":  {config: map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := map[string]tftypes.Value{}
+			for k, typ := range schemaType.AttributeTypes {
+				values[k] = tftypes.NewValue(typ, nil)
+			}
+			for k, v := range tt.config {
+				values[k] = v
+			}
+
+			var resp datasource.ValidateConfigResponse
+			d.ValidateConfig(ctx, datasource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, values)},
+			}, &resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
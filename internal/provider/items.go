@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// findItems lists items through the inventory service find-items endpoint.
+func findItems(ctx context.Context, c *client.Client, params client.FindItemsParams) ([]client.Item, error) {
+	itemsResponse, err := c.FindItems(ctx, &params)
+	if err != nil {
+		return nil, requestError(ctx, err)
+	}
+	defer itemsResponse.Body.Close()
+
+	if itemsResponse.StatusCode != http.StatusOK {
+		return nil, statusError(itemsResponse.StatusCode, fmt.Errorf("unexpected HTTP error code received for Items: %s", itemsResponse.Status))
+	}
+
+	var items []client.Item
+	if err := json.NewDecoder(itemsResponse.Body).Decode(&items); err != nil {
+		return nil, fmt.Errorf("invalid format received for Items: %w", err)
+	}
+
+	return items, nil
+}
+
+// itemSummaryModel maps a single item returned by the data sources that list
+// items.
+type itemSummaryModel struct {
+	ID     types.Int64  `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Tag    types.String `tfsdk:"tag"`
+	Labels types.Map    `tfsdk:"labels"`
+}
+
+// itemSummaryAttributes defines the schema for itemSummaryModel.
+func itemSummaryAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			Description: "Identifier for this inventory item.",
+			Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			Description: "The name for this inventory item.",
+			Computed:    true,
+		},
+		"tag": schema.StringAttribute{
+			Description: "The tag for this inventory item.",
+			Computed:    true,
+		},
+		"labels": schema.MapAttribute{
+			Description: "The labels decoded from the tag for this inventory item.",
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+	}
+}
+
+// newItemSummary maps an item returned by the inventory service to an
+// itemSummaryModel.
+func newItemSummary(ctx context.Context, c *tagCipher, item client.Item) (itemSummaryModel, diag.Diagnostics) {
+	storedTag, err := decodeItemTag(c, item)
+	if err != nil {
+		return itemSummaryModel{}, diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"Unable to Decrypt Item Tag",
+				fmt.Sprintf("The tag for item %d could not be decrypted: %s", item.Id, err),
+			),
+		}
+	}
+
+	labels, diags := storedLabels(ctx, storedTag.Labels)
+
+	return itemSummaryModel{
+		ID:     types.Int64Value(item.Id),
+		Name:   types.StringValue(item.Name),
+		Tag:    types.StringValue(storedTag.Tag),
+		Labels: labels,
+	}, diags
+}
+
+// undecryptableTagsWarning reports the items that a data source listing the
+// inventory skipped because their tags could not be decrypted, such as items
+// encrypted with a key that has since been rotated out.
+func undecryptableTagsWarning(count int, result string) diag.Diagnostic {
+	return diag.NewWarningDiagnostic(
+		"Skipped Items With Undecryptable Tags",
+		fmt.Sprintf("The tags of %d items could not be decrypted, so they are not included in the %s.", count, result),
+	)
+}
+
+// sortItemSummaries orders items by identifier.
+func sortItemSummaries(items []itemSummaryModel) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID.ValueInt64() < items[j].ID.ValueInt64()
+	})
+}
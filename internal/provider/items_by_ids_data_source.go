@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultItemsByIDsConcurrency is the number of items fetched at once when
+// concurrency is not set.
+const defaultItemsByIDsConcurrency = 8
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &itemsByIDsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &itemsByIDsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &itemsByIDsDataSource{}
+)
+
+// NewItemsByIDsDataSource is a helper function to simplify the provider implementation.
+func NewItemsByIDsDataSource() datasource.DataSource {
+	return &itemsByIDsDataSource{}
+}
+
+// itemsByIDsDataSource is the data source implementation.
+type itemsByIDsDataSource struct {
+	client *client.Client
+	cipher *tagCipher
+}
+
+// itemsByIDsDataSourceModel maps the data source schema data.
+type itemsByIDsDataSourceModel struct {
+	IDs           types.Set                   `tfsdk:"ids"`
+	FailOnMissing types.Bool                  `tfsdk:"fail_on_missing"`
+	Concurrency   types.Int64                 `tfsdk:"concurrency"`
+	Items         map[string]itemSummaryModel `tfsdk:"items"`
+	MissingIDs    []int64                     `tfsdk:"missing_ids"`
+}
+
+// fetchedItem is the result of fetching a single item.
+type fetchedItem struct {
+	id   int64
+	item *client.Item
+	err  error
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *itemsByIDsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	d.client = data.client
+	d.cipher = data.cipher
+}
+
+// Metadata returns the data source type name.
+func (d *itemsByIDsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_items_by_ids"
+}
+
+// Schema defines the schema for the data source.
+func (d *itemsByIDsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetch many items by identifier at once, instead of using the inventory_item data source with for_each.",
+		Attributes: map[string]schema.Attribute{
+			"ids": schema.SetAttribute{
+				Description: "Identifiers of the items to fetch.",
+				ElementType: types.Int64Type,
+				Required:    true,
+			},
+			"fail_on_missing": schema.BoolAttribute{
+				Description: "Fail when any of the items does not exist, instead of reporting it in missing_ids.",
+				Optional:    true,
+			},
+			"concurrency": schema.Int64Attribute{
+				Description: fmt.Sprintf("How many items are fetched at once. Defaults to %d.", defaultItemsByIDsConcurrency),
+				Optional:    true,
+			},
+			"items": schema.MapNestedAttribute{
+				Description: "The items that exist, keyed by identifier.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: itemSummaryAttributes(),
+				},
+			},
+			"missing_ids": schema.SetAttribute{
+				Description: "Identifiers of the items that do not exist.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the data source configuration.
+func (d *itemsByIDsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var concurrency types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("concurrency"), &concurrency)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkMinimumAttribute(concurrency, 1, path.Root("concurrency"), "Invalid Concurrency", "concurrency")...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *itemsByIDsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read items by IDs data source")
+	var state itemsByIDsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(state.IDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	resp.Diagnostics.Append(checkMinimumAttribute(state.Concurrency, 1, path.Root("concurrency"), "Invalid Concurrency", "concurrency")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	concurrency := defaultItemsByIDsConcurrency
+	if !state.Concurrency.IsNull() {
+		concurrency = int(state.Concurrency.ValueInt64())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	results := fetchItems(ctx, d.client, ids, concurrency)
+
+	state.Items = make(map[string]itemSummaryModel, len(results))
+	state.MissingIDs = []int64{}
+	var unreadIDs []int64
+	var unreadErr error
+	for _, result := range results {
+		// Once the read times out, every remaining item fails the same way,
+		// so they are reported together.
+		if errors.Is(result.err, context.DeadlineExceeded) || errors.Is(result.err, context.Canceled) {
+			unreadIDs = append(unreadIDs, result.id)
+			unreadErr = result.err
+			continue
+		}
+		if result.err != nil {
+			resp.Diagnostics.Append(clientErrorDiagnostic(fmt.Sprintf("Unable to Read Item %d", result.id), "read", defaultReadTimeout, result.err))
+			continue
+		}
+		if result.item == nil {
+			state.MissingIDs = append(state.MissingIDs, result.id)
+			continue
+		}
+
+		summary, diags := newItemSummary(ctx, d.cipher, *result.item)
+		resp.Diagnostics.Append(diags...)
+		state.Items[strconv.FormatInt(result.id, 10)] = summary
+	}
+	if len(unreadIDs) > 0 {
+		resp.Diagnostics.Append(clientErrorDiagnostic(
+			"Unable to Read Items",
+			"read",
+			defaultReadTimeout,
+			fmt.Errorf("the following items were not read: %s: %w", formatItemIDs(unreadIDs), unreadErr),
+		))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.FailOnMissing.ValueBool() && len(state.MissingIDs) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ids"),
+			"Items Not Found",
+			fmt.Sprintf("The following items do not exist: %s.", formatItemIDs(state.MissingIDs)),
+		)
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading items by IDs data source", map[string]any{"success": true, "count": len(state.Items), "missing": len(state.MissingIDs)})
+}
+
+// formatItemIDs lists item identifiers for diagnostics.
+func formatItemIDs(ids []int64) string {
+	formatted := make([]string, len(ids))
+	for i, id := range ids {
+		formatted[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// fetchItems reads items with at most concurrency requests in flight, and at
+// least one. The results are in the order of ids, and hold a nil item for
+// items that do not exist. Items that were not read before the context is
+// done hold the context error.
+func fetchItems(ctx context.Context, c *client.Client, ids []int64, concurrency int) []fetchedItem {
+	results := make([]fetchedItem, len(ids))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(max(concurrency, 1), len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				item, _, err := findItemForWaiter(ctx, c, ids[i])
+				results[i] = fetchedItem{id: ids[i], item: item, err: err}
+			}
+		}()
+	}
+
+	for i := range ids {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			results[i] = fetchedItem{id: ids[i], err: ctx.Err()}
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
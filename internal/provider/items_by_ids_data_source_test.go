@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestItemsByIDsDataSource(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		var id int64
+		_, _ = fmt.Sscanf(r.URL.Path, "/items/%d", &id)
+		switch {
+		case id == 13:
+			w.WriteHeader(http.StatusInternalServerError)
+		case id > 10:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			tag := "USD:79,420|labels:env=prod"
+			_ = json.NewEncoder(w).Encode(client.Item{Id: id, Name: fmt.Sprintf("Item %d", id), Tag: &tag})
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := context.Background()
+	d := &itemsByIDsDataSource{client: c}
+
+	config := func(failOnMissing bool, ids ...int64) map[string]tftypes.Value {
+		values := make([]tftypes.Value, len(ids))
+		for i, id := range ids {
+			values[i] = tftypes.NewValue(tftypes.Number, id)
+		}
+		return map[string]tftypes.Value{
+			"ids":             tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, values),
+			"fail_on_missing": tftypes.NewValue(tftypes.Bool, failOnMissing),
+			"concurrency":     tftypes.NewValue(tftypes.Number, 3),
+		}
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		resp := readDataSource(t, d, config(false, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12))
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var state itemsByIDsDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if len(state.Items) != 10 {
+			t.Errorf("expected 10 items, got %d", len(state.Items))
+		}
+		if item := state.Items["7"]; item.Name.ValueString() != "Item 7" || item.Tag.ValueString() != "USD:79,420" {
+			t.Errorf("expected item 7 to be decoded, got %v", item)
+		}
+		if len(state.MissingIDs) != 2 || state.MissingIDs[0] != 11 || state.MissingIDs[1] != 12 {
+			t.Errorf("expected items 11 and 12 to be missing, got %v", state.MissingIDs)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if maxInFlight > 3 {
+			t.Errorf("expected at most 3 requests at once, got %d", maxInFlight)
+		}
+	})
+
+	t.Run("fail on missing", func(t *testing.T) {
+		resp := readDataSource(t, d, config(true, 1, 11))
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		resp := readDataSource(t, d, config(false, 1, 13))
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("invalid concurrency", func(t *testing.T) {
+		// A concurrency that was unknown during validation is only checked
+		// when reading.
+		values := config(false, 1, 2)
+		values["concurrency"] = tftypes.NewValue(tftypes.Number, 0)
+		resp := readDataSource(t, d, values)
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Invalid Concurrency" {
+			t.Errorf("expected an invalid concurrency error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("canceled read", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		cfg := dataSourceConfig(t, d, config(false, 1, 2, 3, 4))
+		resp := datasource.ReadResponse{
+			State: tfsdk.State{Schema: cfg.Schema, Raw: tftypes.NewValue(cfg.Raw.Type(), nil)},
+		}
+		d.Read(ctx, datasource.ReadRequest{Config: cfg}, &resp)
+
+		want := "the following items were not read: 1, 2, 3, 4: context canceled"
+		if len(resp.Diagnostics) != 1 || !strings.Contains(resp.Diagnostics[0].Detail(), want) {
+			t.Errorf("expected a single error containing %q, got %v", want, resp.Diagnostics)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		done := make(chan []fetchedItem)
+		go func() { done <- fetchItems(ctx, c, []int64{1, 2, 3, 4}, 0) }()
+
+		select {
+		case results := <-done:
+			for _, result := range results {
+				if result.err == nil && result.item == nil {
+					t.Errorf("expected item %d to be read or fail, got %v", result.id, result)
+				}
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected fetching items to stop with the context")
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &orphansDataSource{}
+	_ datasource.DataSourceWithConfigure = &orphansDataSource{}
+)
+
+// NewOrphansDataSource is a helper function to simplify the provider implementation.
+func NewOrphansDataSource() datasource.DataSource {
+	return &orphansDataSource{}
+}
+
+// orphansDataSource is the data source implementation.
+type orphansDataSource struct {
+	client  *client.Client
+	ownerID string
+	cipher  *tagCipher
+}
+
+// orphansDataSourceModel maps the data source schema data.
+type orphansDataSourceModel struct {
+	OwnerID    types.String       `tfsdk:"owner_id"`
+	ExcludeIDs types.Set          `tfsdk:"exclude_ids"`
+	Items      []itemSummaryModel `tfsdk:"items"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *orphansDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	d.client = data.client
+	d.ownerID = data.ownerID
+	d.cipher = data.cipher
+}
+
+// Metadata returns the data source type name.
+func (d *orphansDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orphans"
+}
+
+// Schema defines the schema for the data source.
+func (d *orphansDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the items stamped with an owner ID, such as items left behind by a deleted Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"owner_id": schema.StringAttribute{
+				Description: "The owner ID to search for. Defaults to the provider owner_id.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"exclude_ids": schema.SetAttribute{
+				Description: "Identifiers of items that are still managed and should be left out of the results.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "The items stamped with the owner ID, ordered by identifier.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: itemSummaryAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *orphansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read orphans data source")
+	var state orphansDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ownerID := d.ownerID
+	if !state.OwnerID.IsNull() {
+		ownerID = state.OwnerID.ValueString()
+	}
+
+	if ownerID == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("owner_id"),
+			"Missing Owner ID",
+			"Set the owner_id attribute on the data source, or the owner_id attribute or INVENTORY_OWNER_ID environment variable on the provider.",
+		)
+		return
+	}
+
+	var excludeIDs []int64
+	if !state.ExcludeIDs.IsNull() {
+		resp.Diagnostics.Append(state.ExcludeIDs.ElementsAs(ctx, &excludeIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	excluded := make(map[int64]bool, len(excludeIDs))
+	for _, id := range excludeIDs {
+		excluded[id] = true
+	}
+
+	items, err := findItems(ctx, d.client, client.FindItemsParams{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Items",
+			err.Error(),
+		)
+		return
+	}
+
+	state.OwnerID = types.StringValue(ownerID)
+	state.Items = []itemSummaryModel{}
+
+	var undecryptable int
+	for _, item := range items {
+		if excluded[item.Id] {
+			continue
+		}
+
+		// The owner of items with undecryptable tags is unknown, so they
+		// are counted and reported instead.
+		storedTag, err := decodeItemTag(d.cipher, item)
+		if err != nil {
+			tflog.Debug(ctx, "Skipping item with undecryptable tag", map[string]any{"id": item.Id, "error": err.Error()})
+			undecryptable++
+			continue
+		}
+		if storedTag.Owner != ownerID {
+			continue
+		}
+
+		summary, diags := newItemSummary(ctx, d.cipher, item)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Items = append(state.Items, summary)
+	}
+
+	if undecryptable > 0 {
+		resp.Diagnostics.Append(undecryptableTagsWarning(undecryptable, "orphans"))
+	}
+
+	sortItemSummaries(state.Items)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading orphans data source", map[string]any{"success": true, "count": len(state.Items)})
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOrphansDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "inventory" {
+  owner_id = "acc-test-orphans"
+}
+
+resource "inventory_item" "managed" {
+  name = "1967 Shelby GT500 Eleanor"
+  tag  = "USD:150,000"
+}
+
+resource "inventory_item" "orphan" {
+  name = "1969 Dodge Charger"
+  tag  = "USD:95,000"
+}
+
+data "inventory_orphans" "test" {
+  exclude_ids = [inventory_item.managed.id]
+
+  depends_on = [inventory_item.orphan]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("inventory_item.managed", "owner_id", "acc-test-orphans"),
+					resource.TestCheckResourceAttr("data.inventory_orphans.test", "owner_id", "acc-test-orphans"),
+					resource.TestCheckResourceAttr("data.inventory_orphans.test", "items.#", "1"),
+					resource.TestCheckResourceAttr("data.inventory_orphans.test", "items.0.name", "1969 Dodge Charger"),
+					resource.TestCheckResourceAttr("data.inventory_orphans.test", "items.0.tag", "USD:95,000"),
+				),
+			},
+		},
+	})
+}
+
+func TestOrphansDataSourceUndecryptable(t *testing.T) {
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+	oldCipher, err := newTagCipher(oldKey, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	newCipher, err := newTagCipher(newKey, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The second item was encrypted with a key that has been rotated out.
+	c := newItemListClient(t, []client.Item{
+		{Id: 1, Name: "1969 Dodge Charger", Tag: testSealedTag(t, newCipher, "USD:95,000|owner:ws-1", "1969 Dodge Charger")},
+		{Id: 2, Name: "1970 Plymouth Barracuda", Tag: testSealedTag(t, oldCipher, "USD:80,000|owner:ws-1", "1970 Plymouth Barracuda")},
+	})
+
+	d := &orphansDataSource{client: c, ownerID: "ws-1", cipher: newCipher}
+	resp := readDataSource(t, d, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state orphansDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(state.Items) != 1 || state.Items[0].ID.ValueInt64() != 1 {
+		t.Errorf("expected item 1, got %v", state.Items)
+	}
+
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || warnings[0].Detail() != "The tags of 1 items could not be decrypted, so they are not included in the orphans." {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseTagFunction{}
+
+// priceAttributeTypes are the attributes of the object returned by parse_tag.
+var priceAttributeTypes = map[string]attr.Type{
+	"currency":  types.StringType,
+	"amount":    types.NumberType,
+	"remainder": types.StringType,
+}
+
+// NewParseTagFunction is a helper function to simplify the provider implementation.
+func NewParseTagFunction() function.Function {
+	return &parseTagFunction{}
+}
+
+// parseTagFunction is the function implementation.
+type parseTagFunction struct{}
+
+// Metadata returns the function name.
+func (f *parseTagFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_tag"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseTagFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a price tag",
+		Description: "Parse a tag of the form CURRENCY:amount, such as USD:79,420, into an object with the currency, the amount as a number, " +
+			"and the remainder of the tag after the amount. An optional locale, such as de, selects the thousands and decimal separators.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "tag",
+				Description: "The tag to parse.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "locale",
+			Description: "The locale of the separators in the amount. Defaults to en.",
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: priceAttributeTypes,
+		},
+	}
+}
+
+// Run parses the tag.
+func (f *parseTagFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tag string
+	var locales []string
+
+	resp.Error = req.Arguments.Get(ctx, &tag, &locales)
+	if resp.Error != nil {
+		return
+	}
+
+	format, funcErr := functionPriceFormat(1, locales)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	price, err := parsePriceTagFormat(tag, format)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(priceAttributeTypes, map[string]attr.Value{
+		"currency":  types.StringValue(price.Currency),
+		"amount":    types.NumberValue(ratBigFloat(price.Amount)),
+		"remainder": types.StringValue(price.Remainder),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// functionPriceFormat returns the separators for the optional locale argument
+// of a function, which is at the given argument position.
+func functionPriceFormat(argument int64, locales []string) (priceFormat, *function.FuncError) {
+	switch len(locales) {
+	case 0:
+		return canonicalPriceFormat, nil
+	case 1:
+		format, err := localePriceFormat(locales[0])
+		if err != nil {
+			return priceFormat{}, function.NewArgumentFuncError(argument, err.Error())
+		}
+		return format, nil
+	default:
+		return priceFormat{}, function.NewArgumentFuncError(argument+1, fmt.Sprintf("expected at most one locale, got %d", len(locales)))
+	}
+}
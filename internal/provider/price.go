@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Rounding rules for price amounts.
+const (
+	roundingNearest = "nearest"
+	roundingUp      = "up"
+	roundingDown    = "down"
+)
+
+// currencyMinorUnits holds the ISO 4217 minor units of currencies that do not
+// use two decimal places.
+var currencyMinorUnits = map[string]int{
+	"BHD": 3, "BIF": 0, "CLF": 4, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3,
+	"ISK": 0, "JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3,
+	"OMR": 3, "PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "UYI": 0, "UYW": 4,
+	"VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// minorUnits returns the number of decimal places used by a currency.
+func minorUnits(currency string) int {
+	if units, ok := currencyMinorUnits[currency]; ok {
+		return units
+	}
+	return 2
+}
+
+// priceTag is a tag that holds a price.
+type priceTag struct {
+	Currency string
+	Amount   *big.Rat
+	// Decimals is the number of digits after the decimal point.
+	Decimals int
+	// Grouped reports whether thousands are separated.
+	Grouped bool
+	// Remainder is any text after the amount.
+	Remainder string
+	// Format holds the separators used in the amount.
+	Format priceFormat
+}
+
+// priceFormat holds the separators used in price amounts.
+type priceFormat struct {
+	Group   string
+	Decimal string
+}
+
+// canonicalPriceFormat is the format of prices in item tags, such as
+// USD:79,420 or USD:2.99.
+var canonicalPriceFormat = priceFormat{Group: ",", Decimal: "."}
+
+// localePriceFormats holds the separators used by each supported locale,
+// keyed by lower case language tag.
+var localePriceFormats = map[string]priceFormat{
+	"en":    canonicalPriceFormat,
+	"ja":    canonicalPriceFormat,
+	"zh":    canonicalPriceFormat,
+	"de":    {Group: ".", Decimal: ","},
+	"es":    {Group: ".", Decimal: ","},
+	"it":    {Group: ".", Decimal: ","},
+	"nl":    {Group: ".", Decimal: ","},
+	"pt":    {Group: ".", Decimal: ","},
+	"fr":    {Group: " ", Decimal: ","},
+	"pl":    {Group: " ", Decimal: ","},
+	"sv":    {Group: " ", Decimal: ","},
+	"de-ch": {Group: "'", Decimal: "."},
+}
+
+// localePriceFormat returns the separators for a locale such as "de" or
+// "en-GB". Locales without their own entry fall back to their language.
+func localePriceFormat(locale string) (priceFormat, error) {
+	tag := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if f, ok := localePriceFormats[tag]; ok {
+		return f, nil
+	}
+
+	language, _, _ := strings.Cut(tag, "-")
+	if f, ok := localePriceFormats[language]; ok {
+		return f, nil
+	}
+
+	locales := make([]string, 0, len(localePriceFormats))
+	for l := range localePriceFormats {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return priceFormat{}, fmt.Errorf("unsupported locale %q, expected one of %s", locale, strings.Join(locales, ", "))
+}
+
+// priceFormatPatterns holds the compiled pattern of each supported format.
+var priceFormatPatterns = map[priceFormat]*regexp.Regexp{}
+
+func init() {
+	for _, f := range localePriceFormats {
+		priceFormatPatterns[f] = f.compile()
+	}
+}
+
+// pattern returns the expression matching prices in the format.
+func (f priceFormat) pattern() *regexp.Regexp {
+	if p, ok := priceFormatPatterns[f]; ok {
+		return p
+	}
+	return f.compile()
+}
+
+// compile builds the expression matching prices in the format.
+func (f priceFormat) compile() *regexp.Regexp {
+	group, decimal := regexp.QuoteMeta(f.Group), regexp.QuoteMeta(f.Decimal)
+	return regexp.MustCompile(`^([A-Z]{3}):(-?)(\d{1,3}(?:` + group + `\d{3})+|\d+)(?:` + decimal + `(\d+))?(.*)$`)
+}
+
+// parsePriceTag parses a tag holding a price of the form CURRENCY:amount,
+// optionally followed by free text.
+func parsePriceTag(tag string) (priceTag, error) {
+	return parsePriceTagFormat(tag, canonicalPriceFormat)
+}
+
+// parsePriceTagFormat parses a tag holding a price with the given separators.
+func parsePriceTagFormat(tag string, format priceFormat) (priceTag, error) {
+	m := format.pattern().FindStringSubmatch(tag)
+	if m == nil {
+		return priceTag{}, fmt.Errorf("tag %q is not a price of the form CURRENCY:amount", tag)
+	}
+
+	digits := m[2] + strings.ReplaceAll(m[3], format.Group, "")
+	if m[4] != "" {
+		digits += "." + m[4]
+	}
+
+	amount, ok := new(big.Rat).SetString(digits)
+	if !ok {
+		return priceTag{}, fmt.Errorf("tag %q has an invalid amount", tag)
+	}
+
+	return priceTag{
+		Currency:  m[1],
+		Amount:    amount,
+		Decimals:  len(m[4]),
+		Grouped:   strings.Contains(m[3], format.Group),
+		Remainder: m[5],
+		Format:    format,
+	}, nil
+}
+
+// String formats the price tag in the style it was parsed from.
+func (p priceTag) String() string {
+	format := p.Format
+	if format == (priceFormat{}) {
+		format = canonicalPriceFormat
+	}
+	return p.Currency + ":" + formatAmount(p.Amount, p.Decimals, p.Grouped, format) + p.Remainder
+}
+
+// formatAmount formats an amount with the given number of decimals, rounding
+// halves away from zero, and optionally separating thousands.
+func formatAmount(amount *big.Rat, decimals int, grouped bool, format priceFormat) string {
+	s := amount.FloatString(decimals)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	if grouped {
+		integer = groupThousands(integer, format.Group)
+	}
+
+	if hasFraction {
+		return sign + integer + format.Decimal + fraction
+	}
+	return sign + integer
+}
+
+// groupThousands separates the digits of a non-negative integer into groups of
+// three.
+func groupThousands(digits string, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// roundAmount rounds an amount to the given number of decimals. Nearest rounds
+// halves away from zero, up rounds towards positive infinity and down rounds
+// towards negative infinity.
+func roundAmount(amount *big.Rat, decimals int, rounding string) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	scaled := new(big.Rat).Mul(amount, new(big.Rat).SetInt(scale))
+
+	// Euclidean division, so the quotient is the floor of the scaled amount.
+	quotient, remainder := new(big.Int).DivMod(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	if remainder.Sign() != 0 {
+		switch rounding {
+		case roundingUp:
+			quotient.Add(quotient, big.NewInt(1))
+		case roundingNearest:
+			// Compare the fractional part against one half.
+			twice := new(big.Int).Mul(remainder, big.NewInt(2))
+			if c := twice.Cmp(scaled.Denom()); c > 0 || (c == 0 && scaled.Sign() > 0) {
+				quotient.Add(quotient, big.NewInt(1))
+			}
+		}
+	}
+
+	return new(big.Rat).SetFrac(quotient, scale)
+}
+
+// bigFloatRat converts a Terraform number to an exact rational, using the
+// shortest decimal that represents it.
+func bigFloatRat(f *big.Float) *big.Rat {
+	r, _ := new(big.Rat).SetString(f.Text('g', -1))
+	return r
+}
+
+// ratBigFloat converts a rational to a Terraform number.
+func ratBigFloat(r *big.Rat) *big.Float {
+	return new(big.Float).SetPrec(512).SetRat(r)
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testRatesValue returns a rates argument for functions that convert prices.
+func testRatesValue(base string, rates map[string]float64) attr.Value {
+	elements := make(map[string]attr.Value, len(rates))
+	for currency, rate := range rates {
+		elements[currency] = types.NumberValue(big.NewFloat(rate))
+	}
+	return types.ObjectValueMust(exchangeRatesAttributeTypes, map[string]attr.Value{
+		"base":  types.StringValue(base),
+		"rates": types.MapValueMust(types.NumberType, elements),
+	})
+}
+
+func TestSumPricesFunction(t *testing.T) {
+	rates := testRatesValue("USD", map[string]float64{"EUR": 0.8, "JPY": 150})
+
+	tests := []struct {
+		name    string
+		tags    []string
+		rates   []attr.Value
+		want    string
+		wantErr bool
+	}{
+		{name: "whole", tags: []string{"USD:79,420", "USD:580"}, want: "USD:80,000"},
+		// 0.1 + 0.2 is not exact in floating point.
+		{name: "decimal", tags: []string{"USD:0.10", "USD:0.20"}, want: "USD:0.30"},
+		{name: "minor units", tags: []string{"KWD:1.0005", "KWD:1"}, want: "KWD:2.001"},
+		{name: "remainder", tags: []string{"USD:2.99 each", "USD:1"}, want: "USD:3.99"},
+		{name: "converted", tags: []string{"EUR:10", "USD:10", "JPY:1,500"}, rates: []attr.Value{rates}, want: "EUR:26"},
+		{name: "mixed", tags: []string{"USD:1", "EUR:1"}, wantErr: true},
+		{name: "missing rate", tags: []string{"USD:1", "GBP:1"}, rates: []attr.Value{rates}, wantErr: true},
+		{name: "not a price", tags: []string{"USD:1", "Vintage"}, wantErr: true},
+		{name: "empty", tags: []string{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		elements := make([]attr.Value, len(tt.tags))
+		for i, tag := range tt.tags {
+			elements[i] = types.StringValue(tag)
+		}
+		args := []attr.Value{types.ListValueMust(types.StringType, elements)}
+
+		value, funcErr := runFunctionValues(t, NewSumPricesFunction(), args, tt.rates...)
+		if tt.wantErr {
+			if funcErr == nil {
+				t.Errorf("%s: expected an error", tt.name)
+			}
+			continue
+		}
+		if funcErr != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, funcErr)
+			continue
+		}
+		if want := types.StringValue(tt.want); !value.Equal(want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, want, value)
+		}
+	}
+}
+
+func TestScalePriceFunction(t *testing.T) {
+	tests := []struct {
+		tag      string
+		factor   string
+		rounding []string
+		want     string
+		wantErr  bool
+	}{
+		{tag: "USD:79,420", factor: "0.85", want: "USD:67,507"},
+		{tag: "USD:2.99", factor: "3", want: "USD:8.97"},
+		{tag: "USD:1", factor: "0.333", want: "USD:0.33"},
+		{tag: "USD:1", factor: "0.333", rounding: []string{"up"}, want: "USD:0.34"},
+		{tag: "JPY:1,000", factor: "1.0055", want: "JPY:1,006"},
+		{tag: "JPY:1,000", factor: "1.0055", rounding: []string{"down"}, want: "JPY:1,005"},
+		{tag: "USD:1", factor: "2", rounding: []string{"sideways"}, wantErr: true},
+		{tag: "Vintage", factor: "2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		factor, _ := new(big.Float).SetString(tt.factor)
+		args := []attr.Value{types.StringValue(tt.tag), types.NumberValue(factor)}
+
+		value, funcErr := runFunction(t, NewScalePriceFunction(), args, tt.rounding...)
+		if tt.wantErr {
+			if funcErr == nil {
+				t.Errorf("%q * %s: expected an error", tt.tag, tt.factor)
+			}
+			continue
+		}
+		if funcErr != nil {
+			t.Errorf("%q * %s: unexpected error: %s", tt.tag, tt.factor, funcErr)
+			continue
+		}
+		if want := types.StringValue(tt.want); !value.Equal(want) {
+			t.Errorf("%q * %s: expected %s, got %s", tt.tag, tt.factor, want, value)
+		}
+	}
+}
+
+func TestRoundPriceFunction(t *testing.T) {
+	tests := []struct {
+		tag      string
+		rounding []string
+		want     string
+		wantErr  bool
+	}{
+		{tag: "USD:2.999", want: "USD:3.00"},
+		{tag: "USD:2.991", rounding: []string{"up"}, want: "USD:3.00"},
+		{tag: "USD:79,420", want: "USD:79,420.00"},
+		{tag: "JPY:1,000.5 each", want: "JPY:1,001 each"},
+		{tag: "KWD:1.23456", rounding: []string{"down"}, want: "KWD:1.234"},
+		{tag: "USD:1", rounding: []string{"nearest", "up"}, wantErr: true},
+		{tag: "Vintage", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		value, funcErr := runFunction(t, NewRoundPriceFunction(), []attr.Value{types.StringValue(tt.tag)}, tt.rounding...)
+		if tt.wantErr {
+			if funcErr == nil {
+				t.Errorf("%q: expected an error", tt.tag)
+			}
+			continue
+		}
+		if funcErr != nil {
+			t.Errorf("%q: unexpected error: %s", tt.tag, funcErr)
+			continue
+		}
+		if want := types.StringValue(tt.want); !value.Equal(want) {
+			t.Errorf("%q: expected %s, got %s", tt.tag, want, value)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParsePriceTag(t *testing.T) {
+	tests := []struct {
+		tag       string
+		currency  string
+		amount    string
+		decimals  int
+		grouped   bool
+		remainder string
+		wantErr   bool
+	}{
+		{tag: "USD:79,420", currency: "USD", amount: "79420", grouped: true},
+		{tag: "USD:2.99", currency: "USD", amount: "299/100", decimals: 2},
+		{tag: "GBP:1,234,567.5 each", currency: "GBP", amount: "2469135/2", decimals: 1, grouped: true, remainder: " each"},
+		{tag: "JPY:-500", currency: "JPY", amount: "-500"},
+		{tag: "USD:79,42", currency: "USD", amount: "79", remainder: ",42"},
+		{tag: "usd:100", wantErr: true},
+		{tag: "USD:", wantErr: true},
+		{tag: "Vintage", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		p, err := parsePriceTag(tt.tag)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error", tt.tag)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.tag, err)
+			continue
+		}
+
+		want, _ := new(big.Rat).SetString(tt.amount)
+		if p.Currency != tt.currency || p.Amount.Cmp(want) != 0 || p.Decimals != tt.decimals || p.Grouped != tt.grouped || p.Remainder != tt.remainder {
+			t.Errorf("%q: unexpected price %+v (amount %s)", tt.tag, p, p.Amount.RatString())
+		}
+		if p.String() != tt.tag {
+			t.Errorf("%q: expected the price to format as it was parsed, got %q", tt.tag, p.String())
+		}
+	}
+}
+
+func TestRoundAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals int
+		rounding string
+		want     string
+	}{
+		{"2.5", 0, roundingNearest, "3"},
+		{"-2.5", 0, roundingNearest, "-3"},
+		{"2.49", 0, roundingNearest, "2"},
+		{"-2.4", 0, roundingNearest, "-2"},
+		{"2.01", 0, roundingUp, "3"},
+		{"-2.01", 0, roundingUp, "-2"},
+		{"2.99", 0, roundingDown, "2"},
+		{"-2.01", 0, roundingDown, "-3"},
+		{"2.345", 2, roundingNearest, "2.35"},
+		{"2.3449", 2, roundingNearest, "2.34"},
+		{"67507", 0, roundingNearest, "67507"},
+	}
+
+	for _, tt := range tests {
+		amount, _ := new(big.Rat).SetString(tt.amount)
+		got := roundAmount(amount, tt.decimals, tt.rounding).FloatString(tt.decimals)
+		if got != tt.want {
+			t.Errorf("round %s to %d decimals %s: expected %s, got %s", tt.amount, tt.decimals, tt.rounding, tt.want, got)
+		}
+	}
+}
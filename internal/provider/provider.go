@@ -2,11 +2,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/superorbital/inventory-service/client"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -17,7 +27,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &inventoryProvider{}
+	_ provider.Provider                       = &inventoryProvider{}
+	_ provider.ProviderWithListResources      = &inventoryProvider{}
+	_ provider.ProviderWithActions            = &inventoryProvider{}
+	_ provider.ProviderWithEphemeralResources = &inventoryProvider{}
+	_ provider.ProviderWithFunctions          = &inventoryProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -39,8 +53,58 @@ type inventoryProvider struct {
 
 // inventoryProviderModel maps provider schema data to a Go type.
 type inventoryProviderModel struct {
-	Host types.String `tfsdk:"host"`
-	Port types.String `tfsdk:"port"`
+	Host          types.String        `tfsdk:"host"`
+	Port          types.String        `tfsdk:"port"`
+	DefaultLabels types.Map           `tfsdk:"default_labels"`
+	LabelsFormat  types.String        `tfsdk:"labels_format"`
+	OwnerID       types.String        `tfsdk:"owner_id"`
+	Encryption    *encryptionModel    `tfsdk:"encryption"`
+	Consistency   *consistencyModel   `tfsdk:"consistency"`
+	ExchangeRates *exchangeRatesModel `tfsdk:"exchange_rates"`
+}
+
+// encryptionModel maps the encryption block schema data.
+type encryptionModel struct {
+	Key            types.String `tfsdk:"key"`
+	KeyEnv         types.String `tfsdk:"key_env"`
+	KeyFile        types.String `tfsdk:"key_file"`
+	DecryptionKeys types.List   `tfsdk:"decryption_keys"`
+}
+
+// consistencyModel maps the consistency block schema data.
+type consistencyModel struct {
+	PollInterval         types.String `tfsdk:"poll_interval"`
+	ConsecutiveSuccesses types.Int64  `tfsdk:"consecutive_successes"`
+}
+
+// exchangeRatesModel maps the exchange_rates block schema data.
+type exchangeRatesModel struct {
+	BaseCurrency types.String `tfsdk:"base_currency"`
+	Rates        types.Map    `tfsdk:"rates"`
+	File         types.String `tfsdk:"file"`
+}
+
+// inventoryProviderData is made available to data sources and resources
+// during their Configure methods.
+type inventoryProviderData struct {
+	client *client.Client
+	// endpoint is the URL of the inventory service.
+	endpoint string
+
+	// defaultLabels are merged into the labels of every managed item.
+	defaultLabels map[string]string
+	// labelsFormat is the format used when encoding labels into item tags.
+	labelsFormat string
+	// ownerID is stamped into the tag of every managed item.
+	ownerID string
+	// cipher encrypts item tags, and is nil when encryption is disabled.
+	cipher *tagCipher
+	// waiter waits for writes to become visible, and is nil when waiting is
+	// disabled.
+	waiter *consistencyWaiter
+	// exchangeRates converts item prices to the base currency, and is nil
+	// when no rates are configured.
+	exchangeRates *exchangeRates
 }
 
 // Metadata returns the provider type name.
@@ -61,8 +125,85 @@ func (p *inventoryProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:    true,
 				Description: "The port to connect to. May also be provided via the INVENTORY_PORT environment variable.",
 			},
+			"default_labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Labels that are merged into the labels of every inventory item managed by this provider. Labels set on the resource take precedence.",
+			},
+			"labels_format": schema.StringAttribute{
+				Optional:    true,
+				Description: "The format used to encode labels into the item tag, either `kv` for key=value pairs or `json`. Defaults to `kv`.",
+			},
+			"owner_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "An identifier for this Terraform configuration, such as the workspace name, that is recorded in the tag of every inventory item it manages. May also be provided via the INVENTORY_OWNER_ID environment variable.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"encryption": schema.SingleNestedBlock{
+				Description: "Encrypt item tags on the client with AES-256-GCM before they are sent to the inventory service. " +
+					"Keys are base64 encoded 32 byte values. Only one of key, key_env or key_file may be set, and the key is read from the " +
+					"INVENTORY_ENCRYPTION_KEY environment variable when none of them are. " +
+					"Encrypted tags are bound to the item name, so renaming an item outside of Terraform makes its tag unreadable, " +
+					"and tags that are not encrypted are rejected while encryption is configured.",
+				Attributes: map[string]schema.Attribute{
+					"key": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "The key used to encrypt and decrypt item tags. Provider configuration is never persisted to state.",
+					},
+					"key_env": schema.StringAttribute{
+						Optional:    true,
+						Description: "The name of an environment variable holding the key used to encrypt and decrypt item tags.",
+					},
+					"key_file": schema.StringAttribute{
+						Optional:    true,
+						Description: "The path to a file holding the key used to encrypt and decrypt item tags.",
+					},
+					"decryption_keys": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Previous keys that are only used to decrypt item tags, which allows keys to be rotated.",
+					},
+				},
+			},
+			"consistency": schema.SingleNestedBlock{
+				Description: "Wait for writes to become visible before finishing an operation, for inventory services with replicated storage. " +
+					"After a create the provider polls until the item can be read, after an update until the item reads back with the new values, " +
+					"and after a delete until the item is no longer found. Server errors and dropped connections are retried while waiting, which is bounded by the resource timeouts.",
+				Attributes: map[string]schema.Attribute{
+					"poll_interval": schema.StringAttribute{
+						Optional:    true,
+						Description: "How long to wait between reads, such as \"500ms\" or \"2s\". Defaults to \"1s\".",
+					},
+					"consecutive_successes": schema.Int64Attribute{
+						Optional:    true,
+						Description: "How many reads in a row must observe the change before it is considered visible. Defaults to 1.",
+					},
+				},
+			},
+			"exchange_rates": schema.SingleNestedBlock{
+				Description: "Exchange rates used to convert item prices to a base currency, for the base_amount attributes. " +
+					"Each rate is the number of units of a currency that one unit of the base currency buys.",
+				Attributes: map[string]schema.Attribute{
+					"base_currency": schema.StringAttribute{
+						Optional:    true,
+						Description: "The ISO 4217 code of the currency prices are converted to, such as USD. Required when the block is set.",
+					},
+					"rates": schema.MapAttribute{
+						ElementType: types.NumberType,
+						Optional:    true,
+						Description: "Rates keyed by currency code, such as { EUR = 0.92 }. Takes precedence over rates read from file.",
+					},
+					"file": schema.StringAttribute{
+						Optional: true,
+						Description: "Path to a JSON file holding rates, such as {\"base\": \"USD\", \"rates\": {\"EUR\": 0.92}}. " +
+							"The base in the file is optional, and must match base_currency when set.",
+					},
+				},
+			},
 		},
-		Blocks:      map[string]schema.Block{},
 		Description: "Interface with the Inventory service API.",
 	}
 }
@@ -102,6 +243,33 @@ func (p *inventoryProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.DefaultLabels.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_labels"),
+			"Unknown Inventory default labels",
+			"The provider cannot configure the default labels as there is an unknown configuration value for the default labels. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.LabelsFormat.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("labels_format"),
+			"Unknown Inventory labels format",
+			"The provider cannot configure the labels format as there is an unknown configuration value for the labels format. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.OwnerID.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("owner_id"),
+			"Unknown Inventory owner ID",
+			"The provider cannot configure the owner ID as there is an unknown configuration value for the owner ID. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the INVENTORY_OWNER_ID environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -111,6 +279,7 @@ func (p *inventoryProvider) Configure(ctx context.Context, req provider.Configur
 
 	host := os.Getenv("INVENTORY_HOST")
 	port := os.Getenv("INVENTORY_PORT")
+	ownerID := os.Getenv("INVENTORY_OWNER_ID")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
@@ -120,6 +289,10 @@ func (p *inventoryProvider) Configure(ctx context.Context, req provider.Configur
 		port = config.Port.ValueString()
 	}
 
+	if !config.OwnerID.IsNull() {
+		ownerID = config.OwnerID.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -145,6 +318,45 @@ func (p *inventoryProvider) Configure(ctx context.Context, req provider.Configur
 		port = "8080"
 	}
 
+	defaultLabels := map[string]string{}
+	if !config.DefaultLabels.IsNull() {
+		resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &defaultLabels, false)...)
+	}
+
+	labelsFormat := labelsFormatKeyValue
+	if !config.LabelsFormat.IsNull() {
+		labelsFormat = config.LabelsFormat.ValueString()
+	}
+
+	if labelsFormat != labelsFormatKeyValue && labelsFormat != labelsFormatJSON {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("labels_format"),
+			"Invalid Inventory labels format",
+			"The labels format must be either \""+labelsFormatKeyValue+"\" or \""+labelsFormatJSON+"\", got: \""+labelsFormat+"\".",
+		)
+	}
+
+	var tagCipher *tagCipher
+	if config.Encryption != nil {
+		var diags diag.Diagnostics
+		tagCipher, diags = p.configureEncryption(ctx, config.Encryption)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	var waiter *consistencyWaiter
+	if config.Consistency != nil {
+		var diags diag.Diagnostics
+		waiter, diags = p.configureConsistency(config.Consistency)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	var rates *exchangeRates
+	if config.ExchangeRates != nil {
+		var diags diag.Diagnostics
+		rates, diags = p.configureExchangeRates(ctx, config.ExchangeRates)
+		resp.Diagnostics.Append(diags...)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -164,7 +376,7 @@ func (p *inventoryProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 	// Test that we have some basic connectivity
-	_, err = api.FindItemById(ctx, int64(1))
+	probe, err := probeService(ctx, api)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Inventory API Client",
@@ -174,19 +386,298 @@ func (p *inventoryProvider) Configure(ctx context.Context, req provider.Configur
 		)
 		return
 	}
+	// An unhealthy service is only a warning, so that the inventory_service
+	// data source can report it to check blocks.
+	if !probe.healthy() {
+		resp.Diagnostics.AddWarning(
+			"Inventory Service Unhealthy",
+			fmt.Sprintf("The health probe of the Inventory service returned HTTP status %d. "+
+				"Operations on inventory items may fail until the service is healthy.", probe.statusCode),
+		)
+	}
 
 	// Make the Inventory client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = api
-	resp.ResourceData = api
+	data := &inventoryProviderData{
+		client:        api,
+		endpoint:      serverURL,
+		defaultLabels: defaultLabels,
+		labelsFormat:  labelsFormat,
+		ownerID:       ownerID,
+		cipher:        tagCipher,
+		waiter:        waiter,
+		exchangeRates: rates,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.ListResourceData = data
+	resp.ActionData = data
+	resp.EphemeralResourceData = data
 
 	tflog.Info(ctx, "Configured Inventory client", map[string]any{"success": true})
 }
 
+// configureEncryption loads the encryption keys configured in the encryption
+// block.
+func (p *inventoryProvider) configureEncryption(ctx context.Context, config *encryptionModel) (*tagCipher, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	encryptionPath := path.Root("encryption")
+
+	sources := 0
+	for _, v := range []types.String{config.Key, config.KeyEnv, config.KeyFile} {
+		if v.IsUnknown() {
+			diags.AddAttributeError(
+				encryptionPath,
+				"Unknown Inventory encryption key",
+				"The provider cannot configure encryption as there is an unknown configuration value for the encryption key. "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+			return nil, diags
+		}
+		if !v.IsNull() {
+			sources++
+		}
+	}
+
+	if sources > 1 {
+		diags.AddAttributeError(
+			encryptionPath,
+			"Conflicting Inventory encryption keys",
+			"Only one of key, key_env or key_file may be set in the encryption block.",
+		)
+		return nil, diags
+	}
+
+	var encodedKey string
+	keyPath := encryptionPath.AtName("key")
+	switch {
+	case !config.Key.IsNull():
+		encodedKey = config.Key.ValueString()
+	case !config.KeyFile.IsNull():
+		keyPath = encryptionPath.AtName("key_file")
+		contents, err := os.ReadFile(config.KeyFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				keyPath,
+				"Unable to Read Inventory encryption key",
+				"The provider could not read the encryption key file: "+err.Error(),
+			)
+			return nil, diags
+		}
+		encodedKey = string(contents)
+	default:
+		envVar := encryptionKeyEnvVar
+		if !config.KeyEnv.IsNull() {
+			envVar = config.KeyEnv.ValueString()
+			keyPath = encryptionPath.AtName("key_env")
+		}
+		encodedKey = os.Getenv(envVar)
+		if strings.TrimSpace(encodedKey) == "" {
+			diags.AddAttributeError(
+				keyPath,
+				"Missing Inventory encryption key",
+				"The encryption block is configured, but the "+envVar+" environment variable is empty or not set.",
+			)
+			return nil, diags
+		}
+	}
+
+	key, err := parseEncryptionKey(encodedKey)
+	if err != nil {
+		diags.AddAttributeError(
+			keyPath,
+			"Invalid Inventory encryption key",
+			"The provider could not load the encryption key: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	if config.DecryptionKeys.IsUnknown() {
+		diags.AddAttributeError(
+			encryptionPath.AtName("decryption_keys"),
+			"Unknown Inventory decryption keys",
+			"The provider cannot configure encryption as there is an unknown configuration value for the decryption keys. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	var encodedDecryptionKeys []string
+	if !config.DecryptionKeys.IsNull() {
+		diags.Append(config.DecryptionKeys.ElementsAs(ctx, &encodedDecryptionKeys, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	decryptionKeys := make([][]byte, 0, len(encodedDecryptionKeys))
+	for i, encoded := range encodedDecryptionKeys {
+		decryptionKey, err := parseEncryptionKey(encoded)
+		if err != nil {
+			diags.AddAttributeError(
+				encryptionPath.AtName("decryption_keys").AtListIndex(i),
+				"Invalid Inventory decryption key",
+				"The provider could not load the decryption key: "+err.Error(),
+			)
+			return nil, diags
+		}
+		decryptionKeys = append(decryptionKeys, decryptionKey)
+	}
+
+	tagCipher, err := newTagCipher(key, decryptionKeys)
+	if err != nil {
+		diags.AddAttributeError(
+			encryptionPath,
+			"Unable to Configure Inventory encryption",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	return tagCipher, diags
+}
+
+// configureConsistency prepares the waiter configured in the consistency
+// block.
+func (p *inventoryProvider) configureConsistency(config *consistencyModel) (*consistencyWaiter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	consistencyPath := path.Root("consistency")
+
+	if config.PollInterval.IsUnknown() || config.ConsecutiveSuccesses.IsUnknown() {
+		diags.AddAttributeError(
+			consistencyPath,
+			"Unknown Inventory consistency settings",
+			"The provider cannot configure consistency waiting as there is an unknown configuration value in the consistency block. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	waiter := &consistencyWaiter{
+		pollInterval:         defaultConsistencyPollInterval,
+		consecutiveSuccesses: defaultConsistencyConsecutiveSuccesses,
+	}
+
+	if !config.PollInterval.IsNull() {
+		pollInterval, err := time.ParseDuration(config.PollInterval.ValueString())
+		if err != nil || pollInterval <= 0 {
+			diags.AddAttributeError(
+				consistencyPath.AtName("poll_interval"),
+				"Invalid Inventory consistency poll interval",
+				"The poll interval must be a positive duration such as \"500ms\" or \"2s\", got: \""+config.PollInterval.ValueString()+"\".",
+			)
+			return nil, diags
+		}
+		waiter.pollInterval = pollInterval
+	}
+
+	if !config.ConsecutiveSuccesses.IsNull() {
+		if config.ConsecutiveSuccesses.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				consistencyPath.AtName("consecutive_successes"),
+				"Invalid Inventory consistency consecutive successes",
+				"The number of consecutive successes must be at least 1.",
+			)
+			return nil, diags
+		}
+		waiter.consecutiveSuccesses = int(config.ConsecutiveSuccesses.ValueInt64())
+	}
+
+	return waiter, diags
+}
+
+// configureExchangeRates loads the rates of the exchange_rates block.
+func (p *inventoryProvider) configureExchangeRates(ctx context.Context, config *exchangeRatesModel) (*exchangeRates, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ratesPath := path.Root("exchange_rates")
+
+	if config.BaseCurrency.IsUnknown() || config.Rates.IsUnknown() || config.File.IsUnknown() {
+		diags.AddAttributeError(
+			ratesPath,
+			"Unknown Inventory exchange rates",
+			"The provider cannot configure exchange rates as there is an unknown configuration value in the exchange_rates block. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return nil, diags
+	}
+
+	if config.BaseCurrency.IsNull() {
+		diags.AddAttributeError(
+			ratesPath.AtName("base_currency"),
+			"Missing Inventory base currency",
+			"The base_currency attribute must be set in the exchange_rates block.",
+		)
+		return nil, diags
+	}
+	base := config.BaseCurrency.ValueString()
+
+	rates := map[string]*big.Rat{}
+	if !config.File.IsNull() {
+		fileRates, err := readExchangeRatesFile(config.File.ValueString(), base)
+		if err != nil {
+			diags.AddAttributeError(
+				ratesPath.AtName("file"),
+				"Unable to Read Inventory exchange rates",
+				"The provider could not read the exchange rates file: "+err.Error(),
+			)
+			return nil, diags
+		}
+		rates = fileRates
+	}
+
+	if !config.Rates.IsNull() {
+		inline := map[string]types.Number{}
+		diags.Append(config.Rates.ElementsAs(ctx, &inline, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		currencies := make([]string, 0, len(inline))
+		for currency := range inline {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		for _, currency := range currencies {
+			rate := inline[currency]
+			if rate.IsNull() || rate.IsUnknown() {
+				diags.AddAttributeError(
+					ratesPath.AtName("rates").AtMapKey(currency),
+					"Invalid Inventory exchange rates",
+					fmt.Sprintf("The rate for %s must be a known number.", currency),
+				)
+				continue
+			}
+			rates[currency] = bigFloatRat(rate.ValueBigFloat())
+		}
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	x, err := newExchangeRates(base, rates)
+	if err != nil {
+		diags.AddAttributeError(
+			ratesPath,
+			"Invalid Inventory exchange rates",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	tflog.Debug(ctx, "Loaded exchange rates", map[string]any{"base_currency": base, "currencies": x.currencies()})
+	return x, diags
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *inventoryProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewItemDataSource,
+		NewOrphansDataSource,
+		NewStatsDataSource,
+		NewItemsByIDsDataSource,
+		NewItemWaitDataSource,
+		NewServiceDataSource,
+		NewExportDataSource,
+		NewSearchDataSource,
 	}
 }
 
@@ -196,3 +687,36 @@ func (p *inventoryProvider) Resources(_ context.Context) []func() resource.Resou
 		NewItemResource,
 	}
 }
+
+// Functions defines the functions implemented in the provider.
+func (p *inventoryProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseTagFunction,
+		NewFormatTagFunction,
+		NewConvertPriceFunction,
+		NewSumPricesFunction,
+		NewScalePriceFunction,
+		NewRoundPriceFunction,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *inventoryProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewItemLeaseEphemeralResource,
+	}
+}
+
+// Actions defines the actions implemented in the provider.
+func (p *inventoryProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewRepriceAction,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider.
+func (p *inventoryProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewItemListResource,
+	}
+}
@@ -1,8 +1,20 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -31,3 +43,142 @@ var (
 		"inventory": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+// newTestProviderServer returns a provider server configured to use the
+// inventory service at serverURL, along with its schemas.
+func newTestProviderServer(t *testing.T, serverURL string, config map[string]tftypes.Value) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configType, ok := schemaResp.Provider.ValueType().(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected provider schema type %s", schemaResp.Provider.ValueType())
+	}
+	values := map[string]tftypes.Value{}
+	for k, typ := range configType.AttributeTypes {
+		values[k] = tftypes.NewValue(typ, nil)
+	}
+	values["host"] = tftypes.NewValue(tftypes.String, u.Hostname())
+	values["port"] = tftypes.NewValue(tftypes.String, u.Port())
+	for k, v := range config {
+		values[k] = v
+	}
+
+	configValue, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &configValue})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range configureResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unable to configure provider: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	return server, schemaResp
+}
+
+// testTag returns a pointer to a tag, for building test items.
+func testTag(tag string) *string {
+	return &tag
+}
+
+// testSealedTag returns a pointer to the tag encrypted for the named item.
+func testSealedTag(t *testing.T, c *tagCipher, tag string, name string) *string {
+	t.Helper()
+	sealed, err := c.seal(tag, name)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return &sealed
+}
+
+// newItemListClient returns a client for a test server that lists the given
+// items for every request.
+func newItemListClient(t *testing.T, items []client.Item) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(items)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return c
+}
+
+// dataSourceConfig returns a data source configuration with the given values.
+// Attributes without a value are null.
+func dataSourceConfig(t *testing.T, d datasource.DataSource, config map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	schemaType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected schema type %s", schemaResp.Schema.Type())
+	}
+
+	values := map[string]tftypes.Value{}
+	for k, typ := range schemaType.AttributeTypes {
+		values[k] = tftypes.NewValue(typ, nil)
+	}
+	for k, v := range config {
+		values[k] = v
+	}
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, values)}
+}
+
+// readDataSource reads a data source with the given configuration values.
+// Attributes without a value are null.
+func readDataSource(t *testing.T, d datasource.DataSource, config map[string]tftypes.Value) datasource.ReadResponse {
+	t.Helper()
+	cfg := dataSourceConfig(t, d, config)
+
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: cfg.Schema, Raw: tftypes.NewValue(cfg.Raw.Type(), nil)},
+	}
+	d.Read(context.Background(), datasource.ReadRequest{Config: cfg}, &resp)
+	return resp
+}
+
+// validateDataSourceConfig validates a data source configuration with the
+// given values. Attributes without a value are null.
+func validateDataSourceConfig(t *testing.T, d datasource.DataSourceWithValidateConfig, config map[string]tftypes.Value) datasource.ValidateConfigResponse {
+	t.Helper()
+	var resp datasource.ValidateConfigResponse
+	d.ValidateConfig(context.Background(), datasource.ValidateConfigRequest{Config: dataSourceConfig(t, d, config)}, &resp)
+	return resp
+}
+
+// itemFilterType is the type of the filter attribute of the data sources that
+// aggregate items.
+func itemFilterType() tftypes.Object {
+	return tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name_regex": tftypes.String,
+		"tag_regex":  tftypes.String,
+		"labels":     tftypes.Map{ElementType: tftypes.String},
+	}}
+}
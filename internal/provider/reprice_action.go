@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action                   = &repriceAction{}
+	_ action.ActionWithConfigure      = &repriceAction{}
+	_ action.ActionWithValidateConfig = &repriceAction{}
+)
+
+// NewRepriceAction is a helper function to simplify the provider implementation.
+func NewRepriceAction() action.Action {
+	return &repriceAction{}
+}
+
+// repriceAction is the action implementation.
+type repriceAction struct {
+	client       *client.Client
+	labelsFormat string
+	cipher       *tagCipher
+}
+
+// repriceActionModel maps the action schema data.
+type repriceActionModel struct {
+	Filter   *itemFilterModel `tfsdk:"filter"`
+	Currency types.String     `tfsdk:"currency"`
+	Percent  types.Number     `tfsdk:"percent"`
+	Amount   types.Number     `tfsdk:"amount"`
+	Rounding types.String     `tfsdk:"rounding"`
+	DryRun   types.Bool       `tfsdk:"dry_run"`
+}
+
+// priceAdjustment changes a price by a percentage or by an absolute amount.
+type priceAdjustment struct {
+	percent  *big.Rat
+	amount   *big.Rat
+	rounding string
+}
+
+// apply returns the adjusted price, rounded to the decimals of the original.
+func (a priceAdjustment) apply(p priceTag) priceTag {
+	amount := new(big.Rat).Set(p.Amount)
+	if a.percent != nil {
+		factor := new(big.Rat).Quo(a.percent, big.NewRat(100, 1))
+		amount.Add(amount, new(big.Rat).Mul(p.Amount, factor))
+	}
+	if a.amount != nil {
+		amount.Add(amount, a.amount)
+	}
+
+	p.Amount = roundAmount(amount, p.Decimals, a.rounding)
+	return p
+}
+
+// Configure adds the provider configured client to the action.
+func (a *repriceAction) Configure(ctx context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	a.client = data.client
+	a.labelsFormat = data.labelsFormat
+	a.cipher = data.cipher
+}
+
+// Metadata returns the action type name.
+func (a *repriceAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reprice"
+}
+
+// Schema defines the schema for the action.
+func (a *repriceAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Change the price of every item matching a filter. Item tags must hold a price of the form CURRENCY:amount, such as USD:79,420.",
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.SingleNestedAttribute{
+				Description: "Only reprice items matching every condition of this filter.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"name_regex": schema.StringAttribute{
+						Description: "Only reprice items whose name matches this regular expression.",
+						Optional:    true,
+					},
+					"tag_regex": schema.StringAttribute{
+						Description: "Only reprice items whose tag, not including labels or the owner ID, matches this regular expression.",
+						Optional:    true,
+					},
+					"labels": schema.MapAttribute{
+						Description: "Only reprice items carrying all of these labels.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+				},
+			},
+			"currency": schema.StringAttribute{
+				Description: "Only reprice items priced in this currency. Matching items priced in other currencies are skipped.",
+				Required:    true,
+			},
+			"percent": schema.NumberAttribute{
+				Description: "Change prices by this percentage, such as -15 for a 15% discount. Conflicts with amount.",
+				Optional:    true,
+			},
+			"amount": schema.NumberAttribute{
+				Description: "Change prices by this amount, such as -500. Conflicts with percent.",
+				Optional:    true,
+			},
+			"rounding": schema.StringAttribute{
+				Description: "How new prices are rounded to the decimals of the current price: nearest, up or down. Defaults to nearest.",
+				Optional:    true,
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "Report the new prices without changing any items.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the action configuration.
+func (a *repriceAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var config repriceActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Percent.IsUnknown() && !config.Amount.IsUnknown() && config.Percent.IsNull() == config.Amount.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("percent"),
+			"Invalid Price Adjustment",
+			"Exactly one of percent or amount must be set.",
+		)
+	}
+
+	resp.Diagnostics.Append(validateItemFilter(ctx, req.Config, path.Root("filter"))...)
+
+	if !config.Rounding.IsNull() && !config.Rounding.IsUnknown() {
+		switch config.Rounding.ValueString() {
+		case roundingNearest, roundingUp, roundingDown:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rounding"),
+				"Invalid Rounding",
+				fmt.Sprintf("The rounding must be %q, %q or %q, got %q.", roundingNearest, roundingUp, roundingDown, config.Rounding.ValueString()),
+			)
+		}
+	}
+}
+
+// Invoke reprices the matching items.
+func (a *repriceAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	tflog.Debug(ctx, "Preparing to invoke reprice action")
+	var config repriceActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := newItemFilter(ctx, config.Filter, path.Root("filter"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adjustment := priceAdjustment{rounding: roundingNearest}
+	if !config.Rounding.IsNull() {
+		adjustment.rounding = config.Rounding.ValueString()
+	}
+	if !config.Percent.IsNull() {
+		adjustment.percent = bigFloatRat(config.Percent.ValueBigFloat())
+	}
+	if !config.Amount.IsNull() {
+		adjustment.amount = bigFloatRat(config.Amount.ValueBigFloat())
+	}
+
+	dryRun := config.DryRun.ValueBool()
+	currency := config.Currency.ValueString()
+
+	listCtx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	items, err := findItems(listCtx, a.client, client.FindItemsParams{})
+	cancel()
+	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to List Items", "read", defaultReadTimeout, err))
+		return
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Id < items[j].Id
+	})
+
+	var repriced, skipped int
+	for _, item := range items {
+		if !filter.matchesName(item) {
+			continue
+		}
+
+		storedTag, err := decodeItemTag(a.cipher, item)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Skipped Item With Undecryptable Tag",
+				fmt.Sprintf("The tag for item %d could not be decrypted, so it was not repriced: %s", item.Id, err),
+			)
+			skipped++
+			continue
+		}
+		if !filter.matches(item, storedTag) {
+			continue
+		}
+
+		price, err := parsePriceTag(storedTag.Tag)
+		if err != nil || price.Currency != currency {
+			sendProgress(resp, fmt.Sprintf("Skipped item %d (%s): tag %q is not a %s price", item.Id, item.Name, storedTag.Tag, currency))
+			skipped++
+			continue
+		}
+
+		newPrice := adjustment.apply(price)
+		if newPrice.Amount.Sign() < 0 {
+			resp.Diagnostics.AddWarning(
+				"Skipped Item With Negative Price",
+				fmt.Sprintf("Repricing item %d (%s) from %s would make its price %s, so it was not repriced.", item.Id, item.Name, price, newPrice),
+			)
+			skipped++
+			continue
+		}
+
+		if dryRun {
+			sendProgress(resp, fmt.Sprintf("Would reprice item %d (%s) from %s to %s", item.Id, item.Name, price, newPrice))
+			repriced++
+			continue
+		}
+
+		storedTag.Tag = newPrice.String()
+		if err := a.updateTag(ctx, item, storedTag); err != nil {
+			resp.Diagnostics.Append(clientErrorDiagnostic(
+				fmt.Sprintf("Unable to Reprice Item %d", item.Id), "update", defaultUpdateTimeout, err))
+			return
+		}
+
+		sendProgress(resp, fmt.Sprintf("Repriced item %d (%s) from %s to %s", item.Id, item.Name, price, newPrice))
+		repriced++
+	}
+
+	summary := fmt.Sprintf("Repriced %d items, skipped %d", repriced, skipped)
+	if dryRun {
+		summary = fmt.Sprintf("Dry run: would reprice %d items, skipped %d", repriced, skipped)
+	}
+	sendProgress(resp, summary)
+	tflog.Debug(ctx, "Finished invoking reprice action", map[string]any{"success": true, "repriced": repriced, "skipped": skipped, "dry_run": dryRun})
+}
+
+// updateTag writes the tag back to the item, keeping its labels and owner.
+func (a *repriceAction) updateTag(ctx context.Context, item client.Item, storedTag itemTag) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultUpdateTimeout)
+	defer cancel()
+
+	encoded, err := storedTag.encode(a.labelsFormat)
+	if err != nil {
+		return err
+	}
+	sealed, err := a.cipher.seal(encoded, item.Name)
+	if err != nil {
+		return err
+	}
+
+	itemResponse, err := a.client.UpdateItem(ctx, item.Id, client.NewItem{Name: item.Name, Tag: &sealed})
+	if err != nil {
+		return err
+	}
+	defer itemResponse.Body.Close()
+
+	if itemResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP error code received for Item: %s", itemResponse.Status)
+	}
+	return nil
+}
+
+// sendProgress reports progress of an action invocation to Terraform.
+func sendProgress(resp *action.InvokeResponse, message string) {
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{Message: message})
+	}
+}
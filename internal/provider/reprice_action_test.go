@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRepriceAction(t *testing.T) {
+	tests := map[string]struct {
+		config      map[string]tftypes.Value
+		wantTags    map[int64]string
+		wantSummary string
+	}{
+		"percent": {
+			config: map[string]tftypes.Value{
+				"filter":  repriceFilter("Jaguar", nil),
+				"percent": tftypes.NewValue(tftypes.Number, big.NewFloat(-15)),
+			},
+			wantTags: map[int64]string{
+				1: "USD:67,507|labels:env=prod|owner:ws-1",
+				2: "USD:2.99",
+				3: "GBP:61,000",
+			},
+			wantSummary: "Repriced 1 items, skipped 1",
+		},
+		"amount rounded up": {
+			config: map[string]tftypes.Value{
+				"amount":   tftypes.NewValue(tftypes.Number, big.NewFloat(0.015)),
+				"rounding": tftypes.NewValue(tftypes.String, roundingUp),
+			},
+			wantTags: map[int64]string{
+				1: "USD:79,421|labels:env=prod|owner:ws-1",
+				2: "USD:3.01",
+				3: "GBP:61,000",
+			},
+			wantSummary: "Repriced 2 items, skipped 1",
+		},
+		"dry run": {
+			config: map[string]tftypes.Value{
+				"percent": tftypes.NewValue(tftypes.Number, big.NewFloat(10)),
+				"dry_run": tftypes.NewValue(tftypes.Bool, true),
+			},
+			wantTags: map[int64]string{
+				1: "USD:79,420|labels:env=prod|owner:ws-1",
+				2: "USD:2.99",
+				3: "GBP:61,000",
+			},
+			wantSummary: "Dry run: would reprice 2 items, skipped 1",
+		},
+	}
+
+	ctx := context.Background()
+	a := &repriceAction{labelsFormat: labelsFormatKeyValue}
+	schemaResp, configType := repriceActionSchema(t, a)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			tag := func(s string) *string { return &s }
+			items := map[int64]client.Item{
+				1: {Id: 1, Name: "1953 Jaguar C-Type", Tag: tag("USD:79,420|labels:env=prod|owner:ws-1")},
+				2: {Id: 2, Name: "Warhead Soda", Tag: tag("USD:2.99")},
+				3: {Id: 3, Name: "1961 Jaguar E-Type", Tag: tag("GBP:61,000")},
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch r.Method {
+				case http.MethodGet:
+					list := make([]client.Item, 0, len(items))
+					for _, item := range items {
+						list = append(list, item)
+					}
+					_ = json.NewEncoder(w).Encode(list)
+				case http.MethodPut:
+					var newItem client.NewItem
+					if err := json.NewDecoder(r.Body).Decode(&newItem); err != nil {
+						t.Errorf("unexpected error: %s", err)
+					}
+					for id := range items {
+						if r.URL.Path == fmt.Sprintf("/items/%d", id) {
+							items[id] = client.Item{Id: id, Name: newItem.Name, Tag: newItem.Tag}
+							_ = json.NewEncoder(w).Encode(items[id])
+						}
+					}
+				}
+			}))
+			defer server.Close()
+
+			c, err := client.NewClient(server.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			a.client = c
+
+			values := map[string]tftypes.Value{}
+			for k, typ := range configType.AttributeTypes {
+				values[k] = tftypes.NewValue(typ, nil)
+			}
+			values["currency"] = tftypes.NewValue(tftypes.String, "USD")
+			for k, v := range tt.config {
+				values[k] = v
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, values)}
+
+			var validateResp action.ValidateConfigResponse
+			a.ValidateConfig(ctx, action.ValidateConfigRequest{Config: config}, &validateResp)
+			if validateResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", validateResp.Diagnostics)
+			}
+
+			var progress []string
+			resp := action.InvokeResponse{
+				SendProgress: func(event action.InvokeProgressEvent) {
+					progress = append(progress, event.Message)
+				},
+			}
+			a.Invoke(ctx, action.InvokeRequest{Config: config}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			for id, want := range tt.wantTags {
+				if got := *items[id].Tag; got != want {
+					t.Errorf("item %d: expected tag %q, got %q", id, want, got)
+				}
+			}
+			if len(progress) == 0 || progress[len(progress)-1] != tt.wantSummary {
+				t.Errorf("expected summary %q, got %q", tt.wantSummary, progress)
+			}
+		})
+	}
+}
+
+// repriceFilter returns a filter with the given regular expressions, where nil
+// leaves an expression null.
+func repriceFilter(nameRegex, tagRegex any) tftypes.Value {
+	return tftypes.NewValue(itemFilterType(), map[string]tftypes.Value{
+		"name_regex": tftypes.NewValue(tftypes.String, nameRegex),
+		"tag_regex":  tftypes.NewValue(tftypes.String, tagRegex),
+		"labels":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+	})
+}
+
+// repriceActionSchema returns the schema of the action and the type of its
+// configuration.
+func repriceActionSchema(t *testing.T, a *repriceAction) (action.SchemaResponse, tftypes.Object) {
+	t.Helper()
+
+	var schemaResp action.SchemaResponse
+	a.Schema(context.Background(), action.SchemaRequest{}, &schemaResp)
+	configType, ok := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected configuration type %s", schemaResp.Schema.Type())
+	}
+	return schemaResp, configType
+}
+
+func TestRepriceActionValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	a := &repriceAction{}
+	schemaResp, configType := repriceActionSchema(t, a)
+
+	tests := map[string]map[string]tftypes.Value{
+		"no adjustment": {},
+		"both adjustments": {
+			"percent": tftypes.NewValue(tftypes.Number, big.NewFloat(10)),
+			"amount":  tftypes.NewValue(tftypes.Number, big.NewFloat(10)),
+		},
+		"invalid regex": {
+			"percent": tftypes.NewValue(tftypes.Number, big.NewFloat(10)),
+			"filter":  repriceFilter("(", nil),
+		},
+		"invalid rounding": {
+			"percent":  tftypes.NewValue(tftypes.Number, big.NewFloat(10)),
+			"rounding": tftypes.NewValue(tftypes.String, "sideways"),
+		},
+	}
+
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := map[string]tftypes.Value{}
+			for k, typ := range configType.AttributeTypes {
+				values[k] = tftypes.NewValue(typ, nil)
+			}
+			values["currency"] = tftypes.NewValue(tftypes.String, "USD")
+			for k, v := range config {
+				values[k] = v
+			}
+
+			var resp action.ValidateConfigResponse
+			a.ValidateConfig(ctx, action.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, values)},
+			}, &resp)
+			if !resp.Diagnostics.HasError() {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRepriceActionInvokeInvalidRegex(t *testing.T) {
+	ctx := context.Background()
+	a := &repriceAction{}
+	schemaResp, configType := repriceActionSchema(t, a)
+
+	// A regular expression that was unknown during validation is only
+	// checked when the action is invoked.
+	values := map[string]tftypes.Value{}
+	for k, typ := range configType.AttributeTypes {
+		values[k] = tftypes.NewValue(typ, nil)
+	}
+	values["currency"] = tftypes.NewValue(tftypes.String, "USD")
+	values["percent"] = tftypes.NewValue(tftypes.Number, big.NewFloat(10))
+	values["filter"] = repriceFilter(nil, "(")
+
+	var resp action.InvokeResponse
+	a.Invoke(ctx, action.InvokeRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, values)},
+	}, &resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Invalid Regular Expression" {
+		t.Errorf("expected an invalid regular expression error, got %v", resp.Diagnostics)
+	}
+}
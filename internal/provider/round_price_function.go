@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &roundPriceFunction{}
+
+// NewRoundPriceFunction is a helper function to simplify the provider implementation.
+func NewRoundPriceFunction() function.Function {
+	return &roundPriceFunction{}
+}
+
+// roundPriceFunction is the function implementation.
+type roundPriceFunction struct{}
+
+// Metadata returns the function name.
+func (f *roundPriceFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "round_price"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *roundPriceFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Round a price tag",
+		Description: "Round the amount of a tag of the form CURRENCY:amount, such as USD:2.999, to the minor units of the currency, " +
+			"such as USD:3.00 or JPY:1,000. The amount keeps its thousands separators and any text after it.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "tag",
+				Description: "The price tag to round.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "rounding",
+			Description: "How the amount is rounded: nearest, up or down. Defaults to nearest.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run rounds the price.
+func (f *roundPriceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tag string
+	var roundings []string
+
+	resp.Error = req.Arguments.Get(ctx, &tag, &roundings)
+	if resp.Error != nil {
+		return
+	}
+
+	price, err := parsePriceTag(tag)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	rounding, funcErr := functionRounding(1, roundings)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	price.Decimals = minorUnits(price.Currency)
+	price.Amount = roundAmount(price.Amount, price.Decimals, rounding)
+	resp.Error = resp.Result.Set(ctx, price.String())
+}
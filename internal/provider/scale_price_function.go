@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &scalePriceFunction{}
+
+// NewScalePriceFunction is a helper function to simplify the provider implementation.
+func NewScalePriceFunction() function.Function {
+	return &scalePriceFunction{}
+}
+
+// scalePriceFunction is the function implementation.
+type scalePriceFunction struct{}
+
+// Metadata returns the function name.
+func (f *scalePriceFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "scale_price"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *scalePriceFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Multiply a price tag",
+		Description: "Multiply the amount of a tag of the form CURRENCY:amount, such as USD:79,420, by a factor using exact decimal arithmetic. " +
+			"The result is rounded to the minor units of the currency, and any text after the amount is dropped.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "tag",
+				Description: "The price tag to multiply.",
+			},
+			function.NumberParameter{
+				Name:        "factor",
+				Description: "The factor, such as 0.85 for a 15% discount.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "rounding",
+			Description: "How the result is rounded: nearest, up or down. Defaults to nearest.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run scales the price.
+func (f *scalePriceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tag string
+	var factor *big.Float
+	var roundings []string
+
+	resp.Error = req.Arguments.Get(ctx, &tag, &factor, &roundings)
+	if resp.Error != nil {
+		return
+	}
+
+	price, err := parsePriceTag(tag)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	rounding, funcErr := functionRounding(2, roundings)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	amount := new(big.Rat).Mul(price.Amount, bigFloatRat(factor))
+	resp.Error = resp.Result.Set(ctx, canonicalPriceTag(price.Currency, amount, rounding, canonicalPriceFormat).String())
+}
+
+// functionRounding returns the optional rounding argument of a function,
+// which is at the given argument position.
+func functionRounding(argument int64, roundings []string) (string, *function.FuncError) {
+	switch len(roundings) {
+	case 0:
+		return roundingNearest, nil
+	case 1:
+		switch roundings[0] {
+		case roundingNearest, roundingUp, roundingDown:
+			return roundings[0], nil
+		}
+		return "", function.NewArgumentFuncError(argument, fmt.Sprintf("the rounding must be %q, %q or %q, got %q", roundingNearest, roundingUp, roundingDown, roundings[0]))
+	default:
+		return "", function.NewArgumentFuncError(argument+1, fmt.Sprintf("expected at most one rounding, got %d", len(roundings)))
+	}
+}
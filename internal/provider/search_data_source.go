@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// searchSortColumns are the columns search results can be sorted by.
+var searchSortColumns = []string{
+	exportColumnID,
+	exportColumnName,
+	exportColumnTag,
+	exportColumnCurrency,
+	exportColumnAmount,
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &searchDataSource{}
+	_ datasource.DataSourceWithConfigure      = &searchDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &searchDataSource{}
+)
+
+// NewSearchDataSource is a helper function to simplify the provider implementation.
+func NewSearchDataSource() datasource.DataSource {
+	return &searchDataSource{}
+}
+
+// searchDataSource is the data source implementation.
+type searchDataSource struct {
+	client *client.Client
+	cipher *tagCipher
+}
+
+// searchDataSourceModel maps the data source schema data.
+type searchDataSourceModel struct {
+	Expression     types.String      `tfsdk:"expression"`
+	SortBy         types.String      `tfsdk:"sort_by"`
+	SortDescending types.Bool        `tfsdk:"sort_descending"`
+	Limit          types.Int64       `tfsdk:"limit"`
+	Items          []searchItemModel `tfsdk:"items"`
+	TotalCount     types.Int64       `tfsdk:"total_count"`
+}
+
+// searchItemModel maps a single item matching the search expression.
+type searchItemModel struct {
+	ID       types.Int64  `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Tag      types.String `tfsdk:"tag"`
+	Labels   types.Map    `tfsdk:"labels"`
+	Currency types.String `tfsdk:"currency"`
+	Amount   types.Number `tfsdk:"amount"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *searchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	d.client = data.client
+	d.cipher = data.cipher
+}
+
+// Metadata returns the data source type name.
+func (d *searchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+// Schema defines the schema for the data source.
+func (d *searchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	itemAttributes := itemSummaryAttributes()
+	itemAttributes["currency"] = schema.StringAttribute{
+		Description: "The currency decoded from a tag of the form CURRENCY:amount, or null for other tags.",
+		Computed:    true,
+	}
+	itemAttributes["amount"] = schema.NumberAttribute{
+		Description: "The amount decoded from a tag of the form CURRENCY:amount, or null for other tags.",
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Search items with a CEL-style filter expression, evaluated by the provider.",
+		Attributes: map[string]schema.Attribute{
+			"expression": schema.StringAttribute{
+				Description: "The condition items must match, such as `currency == \"EUR\" && amount > 100 && name.contains(\"Shelby\")`. " +
+					"The fields id, name, tag, currency and amount can be combined with &&, || and !, compared with ==, !=, <, <=, > and >=, " +
+					"and tested against a list of literals with in. Strings support the contains, startsWith, endsWith and matches methods. " +
+					"The tag does not include labels or the owner ID. The currency and amount are null for items without a price tag, " +
+					"and only compare equal to null.",
+				Required: true,
+			},
+			"sort_by": schema.StringAttribute{
+				Description: "The field to sort items by: " + strings.Join(searchSortColumns, ", ") + ". Items without a price sort last by currency or amount. Defaults to id.",
+				Optional:    true,
+			},
+			"sort_descending": schema.BoolAttribute{
+				Description: "Sort items in descending order.",
+				Optional:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: "The maximum number of items to return, after sorting.",
+				Optional:    true,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "The matching items, in order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: itemAttributes,
+				},
+			},
+			"total_count": schema.Int64Attribute{
+				Description: "The number of matching items, before the limit is applied.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the data source configuration.
+func (d *searchDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config searchDataSourceModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expression"), &config.Expression)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sort_by"), &config.SortBy)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("limit"), &config.Limit)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Expression.IsNull() && !config.Expression.IsUnknown() {
+		if _, err := compileSearchExpression(config.Expression.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expression"),
+				"Invalid Search Expression",
+				searchExpressionErrorDetail(err),
+			)
+		}
+	}
+
+	if !config.SortBy.IsNull() && !config.SortBy.IsUnknown() && !containsString(searchSortColumns, config.SortBy.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sort_by"),
+			"Invalid Sort Column",
+			fmt.Sprintf("The sort column must be one of %s, got %q.", strings.Join(searchSortColumns, ", "), config.SortBy.ValueString()),
+		)
+	}
+
+	resp.Diagnostics.Append(checkMinimumAttribute(config.Limit, 1, path.Root("limit"), "Invalid Limit", "limit")...)
+}
+
+// searchExpressionErrorDetail renders an error compiling a search expression.
+func searchExpressionErrorDetail(err error) string {
+	var exprErr *searchExpressionError
+	if errors.As(err, &exprErr) {
+		return exprErr.detail()
+	}
+	return err.Error()
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *searchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read search data source")
+	var state searchDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expression, err := compileSearchExpression(state.Expression.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expression"),
+			"Invalid Search Expression",
+			searchExpressionErrorDetail(err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(checkMinimumAttribute(state.Limit, 1, path.Root("limit"), "Invalid Limit", "limit")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	items, err := findItems(ctx, d.client, client.FindItemsParams{})
+	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to List Items", "read", defaultReadTimeout, err))
+		return
+	}
+
+	var rows []exportRow
+	var undecryptable int
+	for _, item := range items {
+		row, err := newExportRow(d.cipher, item)
+		if err != nil {
+			tflog.Debug(ctx, "Skipping item with undecryptable tag", map[string]any{"id": item.Id, "error": err.Error()})
+			undecryptable++
+			continue
+		}
+		if expression.matches(row) {
+			rows = append(rows, row)
+		}
+	}
+
+	if undecryptable > 0 {
+		resp.Diagnostics.Append(undecryptableTagsWarning(undecryptable, "search results"))
+	}
+
+	sortBy := exportColumnID
+	if !state.SortBy.IsNull() {
+		sortBy = state.SortBy.ValueString()
+	}
+	sortExportRows(rows, sortBy, state.SortDescending.ValueBool())
+
+	state.TotalCount = types.Int64Value(int64(len(rows)))
+	if !state.Limit.IsNull() && int64(len(rows)) > state.Limit.ValueInt64() {
+		rows = rows[:state.Limit.ValueInt64()]
+	}
+
+	state.Items = make([]searchItemModel, 0, len(rows))
+	for _, row := range rows {
+		labels, diags := storedLabels(ctx, row.storedTag.Labels)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		result := searchItemModel{
+			ID:       types.Int64Value(row.item.Id),
+			Name:     types.StringValue(row.item.Name),
+			Tag:      types.StringValue(row.storedTag.Tag),
+			Labels:   labels,
+			Currency: types.StringNull(),
+			Amount:   types.NumberNull(),
+		}
+		if row.price != nil {
+			result.Currency = types.StringValue(row.price.Currency)
+			result.Amount = types.NumberValue(ratBigFloat(row.price.Amount))
+		}
+		state.Items = append(state.Items, result)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading search data source", map[string]any{"success": true, "count": len(rows)})
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSearchDataSource(t *testing.T) {
+	c := newItemListClient(t, []client.Item{
+		{Id: 1, Name: "1965 Shelby Cobra", Tag: testTag("EUR:120,000.50|labels:env=prod")},
+		{Id: 2, Name: "1966 Shelby GT350", Tag: testTag("EUR:95")},
+		{Id: 3, Name: "1967 Shelby GT500", Tag: testTag("EUR:150,000")},
+		{Id: 4, Name: "1961 Jaguar E-Type", Tag: testTag("USD:79,420")},
+		{Id: 5, Name: "Shelby Floor Mats", Tag: testTag("Vintage")},
+	})
+
+	ctx := context.Background()
+	d := &searchDataSource{client: c}
+
+	read := func(t *testing.T, config map[string]tftypes.Value) searchDataSourceModel {
+		t.Helper()
+		resp := readDataSource(t, d, config)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var state searchDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		return state
+	}
+
+	ids := func(state searchDataSourceModel) []int64 {
+		var ids []int64
+		for _, item := range state.Items {
+			ids = append(ids, item.ID.ValueInt64())
+		}
+		return ids
+	}
+
+	t.Run("expression", func(t *testing.T) {
+		state := read(t, map[string]tftypes.Value{
+			"expression": tftypes.NewValue(tftypes.String, `currency == "EUR" && amount > 100 && name.contains("Shelby")`),
+		})
+
+		if got := ids(state); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+			t.Fatalf("expected items 1 and 3, got %v", got)
+		}
+		item := state.Items[0]
+		if item.Currency.ValueString() != "EUR" || item.Amount.ValueBigFloat().String() != "120000.5" {
+			t.Errorf("unexpected price %s %s", item.Currency, item.Amount)
+		}
+		if item.Labels.Elements()["env"] == nil {
+			t.Errorf("expected the env label, got %v", item.Labels)
+		}
+	})
+
+	t.Run("sort and limit", func(t *testing.T) {
+		state := read(t, map[string]tftypes.Value{
+			"expression":      tftypes.NewValue(tftypes.String, `name.contains("Shelby")`),
+			"sort_by":         tftypes.NewValue(tftypes.String, "amount"),
+			"sort_descending": tftypes.NewValue(tftypes.Bool, true),
+			"limit":           tftypes.NewValue(tftypes.Number, 3),
+		})
+
+		if got := ids(state); len(got) != 3 || got[0] != 3 || got[1] != 1 || got[2] != 2 {
+			t.Errorf("expected items 3, 1 and 2, got %v", got)
+		}
+		if state.TotalCount.ValueInt64() != 4 {
+			t.Errorf("expected 4 matching items, got %d", state.TotalCount.ValueInt64())
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		resp := readDataSource(t, d, map[string]tftypes.Value{
+			"expression": tftypes.NewValue(tftypes.String, `id > 0`),
+			"limit":      tftypes.NewValue(tftypes.Number, 0),
+		})
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Invalid Limit" {
+			t.Errorf("expected an invalid limit error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("missing price", func(t *testing.T) {
+		state := read(t, map[string]tftypes.Value{
+			"expression": tftypes.NewValue(tftypes.String, `amount == null`),
+		})
+
+		if got := ids(state); len(got) != 1 || got[0] != 5 {
+			t.Fatalf("expected item 5, got %v", got)
+		}
+		if !state.Items[0].Currency.IsNull() || !state.Items[0].Amount.IsNull() {
+			t.Errorf("expected a null price, got %s %s", state.Items[0].Currency, state.Items[0].Amount)
+		}
+	})
+}
+
+func TestSearchDataSourceUndecryptable(t *testing.T) {
+	current, err := newTagCipher(bytes.Repeat([]byte{2}, 32), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rotated, err := newTagCipher(bytes.Repeat([]byte{1}, 32), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The second item was encrypted with a key that has been rotated out.
+	c := newItemListClient(t, []client.Item{
+		{Id: 1, Name: "1965 Shelby Cobra", Tag: testSealedTag(t, current, "EUR:120,000.50", "1965 Shelby Cobra")},
+		{Id: 2, Name: "1966 Shelby GT350", Tag: testSealedTag(t, rotated, "EUR:95", "1966 Shelby GT350")},
+	})
+
+	d := &searchDataSource{client: c, cipher: current}
+	resp := readDataSource(t, d, map[string]tftypes.Value{
+		"expression": tftypes.NewValue(tftypes.String, `name.contains("Shelby")`),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state searchDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &state)...)
+	if len(state.Items) != 1 || state.Items[0].ID.ValueInt64() != 1 {
+		t.Errorf("expected item 1, got %v", state.Items)
+	}
+
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || warnings[0].Detail() != "The tags of 1 items could not be decrypted, so they are not included in the search results." {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
+
+func TestSearchDataSourceValidateConfig(t *testing.T) {
+	resp := validateDataSourceConfig(t, &searchDataSource{}, map[string]tftypes.Value{
+		"expression": tftypes.NewValue(tftypes.String, `amount > 100 && nmae.contains("Shelby")`),
+		"sort_by":    tftypes.NewValue(tftypes.String, "labels"),
+		"limit":      tftypes.NewValue(tftypes.Number, 0),
+	})
+
+	if len(resp.Diagnostics) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %v", resp.Diagnostics)
+	}
+	for i, want := range []string{"Invalid Search Expression", "Invalid Sort Column", "Invalid Limit"} {
+		if resp.Diagnostics[i].Summary() != want {
+			t.Errorf("expected %q, got %q", want, resp.Diagnostics[i].Summary())
+		}
+	}
+
+	detail := resp.Diagnostics[0].Detail()
+	if !strings.Contains(detail, `unknown field "nmae", did you mean "name"? at column 17`) || !strings.Contains(detail, "\n                    ^") {
+		t.Errorf("unexpected detail:\n%s", detail)
+	}
+}
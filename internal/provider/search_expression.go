@@ -0,0 +1,698 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// searchExpression is a compiled filter expression of the inventory_search
+// data source. Expressions use a small subset of CEL:
+//
+//	currency == "EUR" && amount > 100 && name.contains("Shelby")
+//
+// Expressions are evaluated client-side against the id, name and tag of each
+// item, and the currency and amount decoded from price tags. The currency and
+// amount are null for items without a price, and every comparison with a
+// null value other than == and != is false.
+type searchExpression struct {
+	root searchNode
+}
+
+// searchType is the static type of a search expression.
+type searchType int
+
+const (
+	searchTypeBool searchType = iota
+	searchTypeNumber
+	searchTypeString
+	searchTypeNull
+	searchTypeList
+)
+
+// String returns the name of the type used in error messages.
+func (t searchType) String() string {
+	switch t {
+	case searchTypeBool:
+		return "bool"
+	case searchTypeNumber:
+		return "number"
+	case searchTypeString:
+		return "string"
+	case searchTypeNull:
+		return "null"
+	case searchTypeList:
+		return "list"
+	}
+	return "unknown"
+}
+
+// searchFields are the fields that can be referenced by search expressions.
+var searchFields = map[string]searchType{
+	"id":       searchTypeNumber,
+	"name":     searchTypeString,
+	"tag":      searchTypeString,
+	"currency": searchTypeString,
+	"amount":   searchTypeNumber,
+}
+
+// searchMethods are the string methods that can be called by search
+// expressions.
+var searchMethods = map[string]func(s string, arg string) bool{
+	"contains":   strings.Contains,
+	"startsWith": strings.HasPrefix,
+	"endsWith":   strings.HasSuffix,
+}
+
+// searchExpressionError describes an invalid search expression, pointing at
+// the offending token.
+type searchExpressionError struct {
+	expression string
+	// offset is the byte offset of the offending token.
+	offset  int
+	message string
+}
+
+// Error returns the message and position of the error. The line is only
+// included for multi-line expressions.
+func (e *searchExpressionError) Error() string {
+	line, column := e.position()
+	if strings.Contains(e.expression, "\n") {
+		return fmt.Sprintf("%s at line %d, column %d", e.message, line, column)
+	}
+	return fmt.Sprintf("%s at column %d", e.message, column)
+}
+
+// position returns the one-based line and column of the offending token,
+// counting columns in characters.
+func (e *searchExpressionError) position() (int, int) {
+	before := e.expression[:e.offset]
+	line := strings.Count(before, "\n") + 1
+	if i := strings.LastIndexByte(before, '\n'); i >= 0 {
+		before = before[i+1:]
+	}
+	return line, utf8.RuneCountInString(before) + 1
+}
+
+// detail renders the error with the line of the expression holding the
+// offending token and a caret under it, for diagnostics.
+func (e *searchExpressionError) detail() string {
+	_, column := e.position()
+	lines := strings.Split(e.expression, "\n")
+	line := lines[strings.Count(e.expression[:e.offset], "\n")]
+	caret := strings.Repeat(" ", column-1) + "^"
+	return fmt.Sprintf("%s:\n\n    %s\n    %s", e.Error(), line, caret)
+}
+
+// searchTokenKind is the kind of a search expression token.
+type searchTokenKind int
+
+const (
+	searchTokenEOF searchTokenKind = iota
+	searchTokenIdent
+	searchTokenNumber
+	searchTokenString
+	searchTokenOperator
+)
+
+// searchToken is a single token of a search expression.
+type searchToken struct {
+	kind searchTokenKind
+	text string
+	// number is the decoded value of number literals.
+	number *big.Rat
+	// str is the decoded value of string literals.
+	str string
+	// offset is the byte offset of the token in the expression.
+	offset int
+}
+
+// literal returns the decoded value of a string or number literal.
+func (t searchToken) literal() any {
+	if t.kind == searchTokenNumber {
+		return t.number
+	}
+	return t.str
+}
+
+// describe returns the token as shown in error messages.
+func (t searchToken) describe() string {
+	if t.kind == searchTokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// searchOperators are the operators and punctuation of search expressions,
+// longest first.
+var searchOperators = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"<", ">", "!", "-", "(", ")", "[", "]", ",", ".",
+}
+
+// searchComparisons are the comparison operators, besides in.
+var searchComparisons = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+}
+
+// searchTypos maps characters that are not operators on their own to the
+// operator they are likely a typo of.
+var searchTypos = map[rune]string{
+	'=': "==",
+	'&': "&&",
+	'|': "||",
+}
+
+// lexSearchExpression splits an expression into tokens, ending with an EOF
+// token.
+func lexSearchExpression(expression string) ([]searchToken, error) {
+	var tokens []searchToken
+	offset := 0
+
+next:
+	for offset < len(expression) {
+		r, size := utf8.DecodeRuneInString(expression[offset:])
+		start := offset
+
+		switch {
+		case unicode.IsSpace(r):
+			offset += size
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			for offset < len(expression) {
+				r, size := utf8.DecodeRuneInString(expression[offset:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				offset += size
+			}
+			tokens = append(tokens, searchToken{kind: searchTokenIdent, text: expression[start:offset], offset: start})
+			continue
+		case r >= '0' && r <= '9':
+			for offset < len(expression) && (expression[offset] >= '0' && expression[offset] <= '9' || expression[offset] == '.') {
+				offset++
+			}
+			text := expression[start:offset]
+			value, ok := new(big.Rat).SetString(text)
+			if !ok || strings.HasSuffix(text, ".") {
+				return nil, &searchExpressionError{expression, start, fmt.Sprintf("invalid number %q", text)}
+			}
+			tokens = append(tokens, searchToken{kind: searchTokenNumber, text: text, number: value, offset: start})
+			continue
+		case r == '"' || r == '\'':
+			value, end, err := lexSearchString(expression, start)
+			if err != nil {
+				return nil, err
+			}
+			offset = end
+			tokens = append(tokens, searchToken{kind: searchTokenString, text: expression[start:offset], str: value, offset: start})
+			continue
+		}
+
+		for _, op := range searchOperators {
+			if strings.HasPrefix(expression[offset:], op) {
+				offset += len(op)
+				tokens = append(tokens, searchToken{kind: searchTokenOperator, text: op, offset: start})
+				continue next
+			}
+		}
+		if op, ok := searchTypos[r]; ok {
+			return nil, &searchExpressionError{expression, start, fmt.Sprintf("unexpected %q, did you mean %q?", r, op)}
+		}
+		return nil, &searchExpressionError{expression, start, fmt.Sprintf("unexpected character %q", r)}
+	}
+
+	return append(tokens, searchToken{kind: searchTokenEOF, offset: len(expression)}), nil
+}
+
+// lexSearchString decodes the quoted string starting at offset, returning its
+// value and the offset following the closing quote.
+func lexSearchString(expression string, offset int) (string, int, error) {
+	quote := expression[offset]
+	var b strings.Builder
+	for i := offset + 1; i < len(expression); i++ {
+		switch c := expression[i]; c {
+		case quote:
+			return b.String(), i + 1, nil
+		case '\n':
+			return "", 0, &searchExpressionError{expression, offset, "unterminated string"}
+		case '\\':
+			i++
+			if i == len(expression) {
+				break
+			}
+			switch e := expression[i]; e {
+			case '\\', '"', '\'':
+				b.WriteByte(e)
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				return "", 0, &searchExpressionError{expression, i - 1, fmt.Sprintf("invalid escape sequence \"\\%c\"", e)}
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, &searchExpressionError{expression, offset, "unterminated string"}
+}
+
+// compileSearchExpression parses and type checks an expression.
+func compileSearchExpression(expression string) (*searchExpression, error) {
+	tokens, err := lexSearchExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &searchParser{expression: expression, tokens: tokens}
+	if p.peek().kind == searchTokenEOF {
+		return nil, p.errorf(p.peek(), "empty expression")
+	}
+
+	start := p.peek()
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != searchTokenEOF {
+		return nil, p.errorf(t, "unexpected %s, expected an operator or end of expression", t.describe())
+	}
+	if root.typ != searchTypeBool {
+		return nil, p.errorf(start, "the expression must be a condition, got a %s", root.typ)
+	}
+
+	return &searchExpression{root: root}, nil
+}
+
+// matches reports whether an item matches the expression.
+func (e *searchExpression) matches(row exportRow) bool {
+	match, _ := e.root.eval(row).(bool)
+	return match
+}
+
+// searchNode is a type checked node of a search expression.
+type searchNode struct {
+	typ  searchType
+	eval func(row exportRow) any
+	// list holds the elements of list literals, which are the only lists.
+	list []any
+}
+
+// searchParser is a recursive descent parser for search expressions. From
+// lowest to highest precedence, expressions are made of || and &&, unary !,
+// comparisons, and method calls on fields and literals.
+type searchParser struct {
+	expression string
+	tokens     []searchToken
+	pos        int
+}
+
+// peek returns the current token.
+func (p *searchParser) peek() searchToken {
+	return p.tokens[p.pos]
+}
+
+// next consumes the current token.
+func (p *searchParser) next() searchToken {
+	t := p.tokens[p.pos]
+	if t.kind != searchTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the current token if it is the given operator.
+func (p *searchParser) accept(op string) bool {
+	if t := p.peek(); t.kind == searchTokenOperator && t.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expect consumes the given operator, or fails.
+func (p *searchParser) expect(op string) error {
+	if !p.accept(op) {
+		t := p.peek()
+		return p.errorf(t, "unexpected %s, expected %q", t.describe(), op)
+	}
+	return nil
+}
+
+// errorf returns an error pointing at a token.
+func (p *searchParser) errorf(t searchToken, format string, args ...any) error {
+	return &searchExpressionError{p.expression, t.offset, fmt.Sprintf(format, args...)}
+}
+
+// parseOr parses a disjunction.
+func (p *searchParser) parseOr() (searchNode, error) {
+	return p.parseLogical("||", p.parseAnd, true)
+}
+
+// parseAnd parses a conjunction.
+func (p *searchParser) parseAnd() (searchNode, error) {
+	return p.parseLogical("&&", p.parseUnary, false)
+}
+
+// parseLogical parses operands joined by a short-circuit operator, which
+// stops at the first operand evaluating to stop.
+func (p *searchParser) parseLogical(op string, operand func() (searchNode, error), stop bool) (searchNode, error) {
+	start := p.peek()
+	left, err := operand()
+	if err != nil {
+		return searchNode{}, err
+	}
+
+	for p.peek().kind == searchTokenOperator && p.peek().text == op {
+		if left.typ != searchTypeBool {
+			return searchNode{}, p.errorf(start, "the operands of %s must be conditions, got a %s", op, left.typ)
+		}
+		p.next()
+		start = p.peek()
+		right, err := operand()
+		if err != nil {
+			return searchNode{}, err
+		}
+		if right.typ != searchTypeBool {
+			return searchNode{}, p.errorf(start, "the operands of %s must be conditions, got a %s", op, right.typ)
+		}
+
+		l, r := left.eval, right.eval
+		left = searchNode{typ: searchTypeBool, eval: func(row exportRow) any {
+			if v, _ := l(row).(bool); v == stop {
+				return stop
+			}
+			v, _ := r(row).(bool)
+			return v
+		}}
+	}
+	return left, nil
+}
+
+// parseUnary parses a negation or a comparison.
+func (p *searchParser) parseUnary() (searchNode, error) {
+	if t := p.peek(); t.kind == searchTokenOperator && t.text == "!" {
+		p.next()
+		start := p.peek()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return searchNode{}, err
+		}
+		if operand.typ != searchTypeBool {
+			return searchNode{}, p.errorf(start, "the operand of ! must be a condition, got a %s", operand.typ)
+		}
+		eval := operand.eval
+		return searchNode{typ: searchTypeBool, eval: func(row exportRow) any {
+			v, _ := eval(row).(bool)
+			return !v
+		}}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses a comparison, or a single operand.
+func (p *searchParser) parseComparison() (searchNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return searchNode{}, err
+	}
+
+	t := p.peek()
+	var op string
+	switch {
+	case t.kind == searchTokenOperator && searchComparisons[t.text]:
+		op = t.text
+	case t.kind == searchTokenIdent && t.text == "in":
+		op = t.text
+	default:
+		return left, nil
+	}
+	p.next()
+
+	start := p.peek()
+	right, err := p.parsePrimary()
+	if err != nil {
+		return searchNode{}, err
+	}
+	l, r := left.eval, right.eval
+
+	switch op {
+	case "in":
+		if left.typ == searchTypeList {
+			return searchNode{}, p.errorf(t, "the left operand of in cannot be a list")
+		}
+		if right.typ != searchTypeList {
+			return searchNode{}, p.errorf(start, "the right operand of in must be a list, got a %s", right.typ)
+		}
+		return searchNode{typ: searchTypeBool, eval: func(row exportRow) any {
+			v := l(row)
+			for _, e := range right.list {
+				if searchEqual(v, e) {
+					return true
+				}
+			}
+			return false
+		}}, nil
+	case "==", "!=":
+		if left.typ == searchTypeList || right.typ == searchTypeList {
+			return searchNode{}, p.errorf(t, "cannot compare lists with %s", op)
+		}
+		if left.typ != right.typ && left.typ != searchTypeNull && right.typ != searchTypeNull {
+			return searchNode{}, p.errorf(t, "cannot compare a %s with a %s", left.typ, right.typ)
+		}
+		return searchNode{typ: searchTypeBool, eval: func(row exportRow) any {
+			return searchEqual(l(row), r(row)) == (op == "==")
+		}}, nil
+	}
+
+	if left.typ != right.typ || (left.typ != searchTypeNumber && left.typ != searchTypeString) {
+		return searchNode{}, p.errorf(t, "cannot order a %s and a %s", left.typ, right.typ)
+	}
+	return searchNode{typ: searchTypeBool, eval: func(row exportRow) any {
+		c, ok := searchCompare(l(row), r(row))
+		if !ok {
+			return false
+		}
+		switch op {
+		case "<":
+			return c < 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		default:
+			return c >= 0
+		}
+	}}, nil
+}
+
+// parsePrimary parses a literal, field, list or parenthesized expression,
+// followed by any method calls.
+func (p *searchParser) parsePrimary() (searchNode, error) {
+	t := p.next()
+
+	var node searchNode
+	switch {
+	case t.kind == searchTokenNumber || t.kind == searchTokenString:
+		node = searchLiteral(t.literal())
+	case t.kind == searchTokenOperator && t.text == "-":
+		n := p.next()
+		if n.kind != searchTokenNumber {
+			return searchNode{}, p.errorf(n, "unexpected %s, expected a number", n.describe())
+		}
+		node = searchLiteral(new(big.Rat).Neg(n.number))
+	case t.kind == searchTokenOperator && t.text == "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return searchNode{}, err
+		}
+		if err := p.expect(")"); err != nil {
+			return searchNode{}, err
+		}
+		node = inner
+	case t.kind == searchTokenOperator && t.text == "[":
+		list, err := p.parseList()
+		if err != nil {
+			return searchNode{}, err
+		}
+		node = list
+	case t.kind == searchTokenIdent:
+		switch t.text {
+		case "true", "false":
+			node = searchLiteral(t.text == "true")
+		case "null":
+			node = searchLiteral(nil)
+		default:
+			typ, ok := searchFields[t.text]
+			if !ok {
+				return searchNode{}, p.errorf(t, "unknown field %q%s", t.text, searchSuggestion(t.text, searchFields))
+			}
+			node = searchNode{typ: typ, eval: searchField(t.text)}
+		}
+	default:
+		return searchNode{}, p.errorf(t, "unexpected %s, expected a field, literal or (", t.describe())
+	}
+
+	for p.accept(".") {
+		call, err := p.parseMethod(node)
+		if err != nil {
+			return searchNode{}, err
+		}
+		node = call
+	}
+	return node, nil
+}
+
+// parseList parses the elements of a list literal, after the opening bracket.
+// Elements must be string or number literals of the same type.
+func (p *searchParser) parseList() (searchNode, error) {
+	var values []any
+	var typ searchType
+	for !p.accept("]") {
+		if len(values) > 0 {
+			if err := p.expect(","); err != nil {
+				return searchNode{}, err
+			}
+		}
+
+		t := p.next()
+		if t.kind != searchTokenString && t.kind != searchTokenNumber {
+			return searchNode{}, p.errorf(t, "unexpected %s, expected a string or number", t.describe())
+		}
+		element := searchLiteral(t.literal())
+		if len(values) > 0 && element.typ != typ {
+			return searchNode{}, p.errorf(t, "list elements must all be of the same type, got a %s and a %s", typ, element.typ)
+		}
+		typ = element.typ
+		values = append(values, t.literal())
+	}
+	return searchNode{typ: searchTypeList, list: values, eval: func(exportRow) any { return values }}, nil
+}
+
+// parseMethod parses a method call on a string, after the dot.
+func (p *searchParser) parseMethod(receiver searchNode) (searchNode, error) {
+	name := p.next()
+	if name.kind != searchTokenIdent {
+		return searchNode{}, p.errorf(name, "unexpected %s, expected a method name", name.describe())
+	}
+	method, ok := searchMethods[name.text]
+	if !ok && name.text != "matches" {
+		return searchNode{}, p.errorf(name, "unknown method %q%s", name.text, searchSuggestion(name.text, searchMethods))
+	}
+	if receiver.typ != searchTypeString {
+		return searchNode{}, p.errorf(name, "%s can only be called on a string, got a %s", name.text, receiver.typ)
+	}
+	if err := p.expect("("); err != nil {
+		return searchNode{}, err
+	}
+	arg := p.next()
+	if arg.kind != searchTokenString {
+		return searchNode{}, p.errorf(arg, "unexpected %s, expected a string argument", arg.describe())
+	}
+	if err := p.expect(")"); err != nil {
+		return searchNode{}, err
+	}
+
+	s := arg.str
+	if name.text == "matches" {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return searchNode{}, p.errorf(arg, "invalid regular expression: %s", err)
+		}
+		method = func(v string, _ string) bool { return re.MatchString(v) }
+	}
+
+	eval := receiver.eval
+	return searchNode{typ: searchTypeBool, eval: func(row exportRow) any {
+		v, ok := eval(row).(string)
+		return ok && method(v, s)
+	}}, nil
+}
+
+// searchSuggestion returns a hint naming the closest known name, if any is
+// close enough to be a typo.
+func searchSuggestion[V any](name string, known map[string]V) string {
+	best, bestDistance := "", 3
+	for k := range known {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(k)); d < bestDistance || (d == bestDistance && k < best) {
+			best, bestDistance = k, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+// searchLiteral returns a node evaluating to a constant.
+func searchLiteral(value any) searchNode {
+	var typ searchType
+	switch value.(type) {
+	case bool:
+		typ = searchTypeBool
+	case *big.Rat:
+		typ = searchTypeNumber
+	case string:
+		typ = searchTypeString
+	default:
+		typ = searchTypeNull
+	}
+	return searchNode{typ: typ, eval: func(exportRow) any { return value }}
+}
+
+// searchField returns the evaluator for a field. Missing prices evaluate to
+// nil.
+func searchField(name string) func(row exportRow) any {
+	switch name {
+	case "id":
+		return func(row exportRow) any { return new(big.Rat).SetInt64(row.item.Id) }
+	case "currency":
+		return func(row exportRow) any {
+			if row.price == nil {
+				return nil
+			}
+			return row.price.Currency
+		}
+	case "amount":
+		return func(row exportRow) any {
+			if row.price == nil {
+				return nil
+			}
+			return row.price.Amount
+		}
+	default:
+		return func(row exportRow) any { return row.text(name) }
+	}
+}
+
+// searchEqual reports whether two values are equal. Null only equals null.
+func searchEqual(a any, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	c, ok := searchCompare(a, b)
+	if ok {
+		return c == 0
+	}
+	return a == b
+}
+
+// searchCompare orders two numbers or two strings. It fails for any other
+// values, including null.
+func searchCompare(a any, b any) (int, bool) {
+	switch a := a.(type) {
+	case *big.Rat:
+		if b, ok := b.(*big.Rat); ok {
+			return a.Cmp(b), true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	}
+	return 0, false
+}
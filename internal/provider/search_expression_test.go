@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+func TestSearchExpression(t *testing.T) {
+	row := func(id int64, name string, tag string) exportRow {
+		r := exportRow{item: client.Item{Id: id, Name: name}, storedTag: itemTag{Tag: tag}}
+		if price, err := parsePriceTag(tag); err == nil {
+			r.price = &price
+		}
+		return r
+	}
+	shelby := row(1, "1965 Shelby Cobra", "EUR:120,000.50")
+	jaguar := row(2, "1961 Jaguar E-Type", "USD:79,420")
+	mats := row(3, "Floor Mats", "Vintage")
+
+	for _, tt := range []struct {
+		expression string
+		want       []int64
+	}{
+		{`currency == "EUR" && amount > 100 && name.contains("Shelby")`, []int64{1}},
+		{`amount >= 79420`, []int64{1, 2}},
+		{`amount < 100000 || tag == 'Vintage'`, []int64{2, 3}},
+		{`amount == null`, []int64{3}},
+		{`currency != null`, []int64{1, 2}},
+		{`!(currency in ["EUR", "GBP"])`, []int64{2, 3}},
+		{`id in [1, 3]`, []int64{1, 3}},
+		{`name.matches("^[0-9]{4} ") && !name.startsWith("1965")`, []int64{2}},
+		{`name.endsWith("Mats") || amount > -1`, []int64{1, 2, 3}},
+		{`tag > "U"`, []int64{2, 3}},
+		{"true &&\n  id != 2", []int64{1, 3}},
+		{`false`, nil},
+	} {
+		t.Run(tt.expression, func(t *testing.T) {
+			expression, err := compileSearchExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []int64
+			for _, r := range []exportRow{shelby, jaguar, mats} {
+				if expression.matches(r) {
+					got = append(got, r.item.Id)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchExpressionErrors(t *testing.T) {
+	for _, tt := range []struct {
+		expression string
+		want       string
+	}{
+		{``, "empty expression at column 1"},
+		{`nmae == "x"`, `unknown field "nmae", did you mean "name"? at column 1`},
+		{`name = "x"`, `unexpected '=', did you mean "=="? at column 6`},
+		{`amount > "100"`, `cannot order a number and a string at column 8`},
+		{`name == 1`, `cannot compare a string with a number at column 6`},
+		{`name && id == 1`, `the operands of && must be conditions, got a string at column 1`},
+		{`!amount`, `the operand of ! must be a condition, got a number at column 2`},
+		{`name`, `the expression must be a condition, got a string at column 1`},
+		{`(id == 1`, `unexpected end of expression, expected ")" at column 9`},
+		{`id == 1 id`, `unexpected "id", expected an operator or end of expression at column 9`},
+		{`name.contain("x")`, `unknown method "contain", did you mean "contains"? at column 6`},
+		{`amount.contains("1")`, `contains can only be called on a string, got a number at column 8`},
+		{`name.matches("(")`, "invalid regular expression: error parsing regexp: missing closing ): `(` at column 14"},
+		{`name.contains(id)`, `unexpected "id", expected a string argument at column 15`},
+		{`currency in ["EUR", 1]`, `list elements must all be of the same type, got a string and a number at column 21`},
+		{`[1] == [1]`, `cannot compare lists with == at column 5`},
+		{`[] != []`, `cannot compare lists with != at column 4`},
+		{`id == [1]`, `cannot compare lists with == at column 4`},
+		{`[1] in [1]`, `the left operand of in cannot be a list at column 5`},
+		{`currency in "EUR"`, `the right operand of in must be a list, got a string at column 13`},
+		{`name == "Shelby`, `unterminated string at column 9`},
+		{`name == "\d"`, `invalid escape sequence "\d" at column 10`},
+		{`amount > 1.2.3`, `invalid number "1.2.3" at column 10`},
+		{`amount > 1 # comment`, `unexpected character '#' at column 12`},
+		{`name == "café" && émoji`, `unknown field "émoji" at column 19`},
+	} {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := compileSearchExpression(tt.expression)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, err.Error())
+			}
+		})
+	}
+}
+
+func TestSearchExpressionErrorDetail(t *testing.T) {
+	_, err := compileSearchExpression("currency == \"EUR\" &&\n  amount > 'x'")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := "cannot order a number and a string at line 2, column 10:\n\n      amount > 'x'\n             ^"
+	if got := searchExpressionErrorDetail(err); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+// serviceProbeItemID is the item read to check that the inventory service is
+// reachable. Whether it exists does not matter.
+const serviceProbeItemID = 1
+
+// serviceProbe is the outcome of a reachable health probe.
+type serviceProbe struct {
+	statusCode int
+	latency    time.Duration
+	// versions holds the headers of the probe response that describe the
+	// server version, such as Server or X-Api-Version.
+	versions map[string]string
+}
+
+// probeService checks basic connectivity with the inventory service. An error
+// means the service could not be reached.
+func probeService(ctx context.Context, c *client.Client) (serviceProbe, error) {
+	start := time.Now()
+	itemResponse, err := c.FindItemById(ctx, serviceProbeItemID)
+	if err != nil {
+		return serviceProbe{}, err
+	}
+	defer itemResponse.Body.Close()
+
+	return serviceProbe{
+		statusCode: itemResponse.StatusCode,
+		latency:    time.Since(start),
+		versions:   versionHeaders(itemResponse.Header),
+	}, nil
+}
+
+// healthy reports whether the probe found the service working. A missing
+// probe item is not a failure.
+func (p serviceProbe) healthy() bool {
+	return p.statusCode == http.StatusOK || p.statusCode == http.StatusNotFound
+}
+
+// versionHeaders returns the Server header and any header naming a version,
+// keyed by canonical header name.
+func versionHeaders(header http.Header) map[string]string {
+	versions := map[string]string{}
+	for name, values := range header {
+		if name != "Server" && !strings.Contains(strings.ToLower(name), "version") {
+			continue
+		}
+		versions[name] = strings.Join(values, ", ")
+	}
+	return versions
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &serviceDataSource{}
+	_ datasource.DataSourceWithConfigure = &serviceDataSource{}
+)
+
+// NewServiceDataSource is a helper function to simplify the provider implementation.
+func NewServiceDataSource() datasource.DataSource {
+	return &serviceDataSource{}
+}
+
+// serviceDataSource is the data source implementation.
+type serviceDataSource struct {
+	client   *client.Client
+	endpoint string
+}
+
+// serviceDataSourceModel maps the data source schema data.
+type serviceDataSourceModel struct {
+	Endpoint   types.String `tfsdk:"endpoint"`
+	Reachable  types.Bool   `tfsdk:"reachable"`
+	Healthy    types.Bool   `tfsdk:"healthy"`
+	Error      types.String `tfsdk:"error"`
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	LatencyMS  types.Int64  `tfsdk:"latency_ms"`
+	Versions   types.Map    `tfsdk:"versions"`
+	ItemCount  types.Int64  `tfsdk:"item_count"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *serviceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	d.client = data.client
+	d.endpoint = data.endpoint
+}
+
+// Metadata returns the data source type name.
+func (d *serviceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service"
+}
+
+// Schema defines the schema for the data source.
+func (d *serviceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Report the health of the inventory service, for use in check blocks and preconditions. " +
+			"An unreachable service is reported in the attributes rather than as an error.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Description: "The URL of the inventory service, resolved from the provider configuration.",
+				Computed:    true,
+			},
+			"reachable": schema.BoolAttribute{
+				Description: "Whether the inventory service responded to the health probe.",
+				Computed:    true,
+			},
+			"healthy": schema.BoolAttribute{
+				Description: "Whether the health probe succeeded. The probe reads item 1, and a response that it does not exist also counts as healthy.",
+				Computed:    true,
+			},
+			"error": schema.StringAttribute{
+				Description: "Why the inventory service could not be reached. Null when it is reachable.",
+				Computed:    true,
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP status code of the health probe. Null when the service is unreachable.",
+				Computed:    true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				Description: "The round-trip time of the health probe in milliseconds. Null when the service is unreachable.",
+				Computed:    true,
+			},
+			"versions": schema.MapAttribute{
+				Description: "The Server header and any header naming a version returned by the health probe, such as X-Api-Version, keyed by header name.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"item_count": schema.Int64Attribute{
+				Description: "The total number of items. Null when the items could not be listed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *serviceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read service data source")
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	state := serviceDataSourceModel{
+		Endpoint:   types.StringValue(d.endpoint),
+		Reachable:  types.BoolValue(false),
+		Healthy:    types.BoolValue(false),
+		Error:      types.StringNull(),
+		StatusCode: types.Int64Null(),
+		LatencyMS:  types.Int64Null(),
+		Versions:   types.MapNull(types.StringType),
+		ItemCount:  types.Int64Null(),
+	}
+
+	probe, err := probeService(ctx, d.client)
+	if err != nil {
+		state.Error = types.StringValue(err.Error())
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		tflog.Debug(ctx, "Finished reading service data source", map[string]any{"success": true, "reachable": false})
+		return
+	}
+
+	versions, diags := types.MapValueFrom(ctx, types.StringType, probe.versions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Reachable = types.BoolValue(true)
+	state.Healthy = types.BoolValue(probe.healthy())
+	state.StatusCode = types.Int64Value(int64(probe.statusCode))
+	state.LatencyMS = types.Int64Value(probe.latency.Milliseconds())
+	state.Versions = versions
+
+	items, err := findItems(ctx, d.client, client.FindItemsParams{})
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Count Items",
+			fmt.Sprintf("The inventory service is reachable, but its items could not be listed: %s", err),
+		)
+	} else {
+		state.ItemCount = types.Int64Value(int64(len(items)))
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading service data source", map[string]any{"success": true, "reachable": true})
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestServiceDataSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "inventory-service")
+		w.Header().Set("X-Api-Version", "1.4.0")
+		w.Header().Set("X-Request-Id", "abc")
+		if r.URL.Path == "/items" {
+			_ = json.NewEncoder(w).Encode([]client.Item{{Id: 2}, {Id: 3}})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	read := func(t *testing.T, serverURL string) serviceDataSourceModel {
+		t.Helper()
+		c, err := client.NewClient(serverURL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		resp := readDataSource(t, &serviceDataSource{client: c, endpoint: serverURL}, nil)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var state serviceDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		return state
+	}
+
+	t.Run("reachable", func(t *testing.T) {
+		state := read(t, server.URL)
+
+		if state.Endpoint.ValueString() != server.URL || !state.Reachable.ValueBool() || !state.Healthy.ValueBool() || !state.Error.IsNull() {
+			t.Errorf("expected a healthy service, got %v", state)
+		}
+		if state.StatusCode.ValueInt64() != http.StatusNotFound || state.LatencyMS.IsNull() {
+			t.Errorf("expected the probe status and latency, got %s %s", state.StatusCode, state.LatencyMS)
+		}
+		if state.ItemCount.ValueInt64() != 2 {
+			t.Errorf("expected 2 items, got %s", state.ItemCount)
+		}
+
+		versions := map[string]string{}
+		state.Versions.ElementsAs(ctx, &versions, false)
+		if len(versions) != 2 || versions["Server"] != "inventory-service" || versions["X-Api-Version"] != "1.4.0" {
+			t.Errorf("expected the version headers, got %v", versions)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		state := read(t, closed.URL)
+
+		if state.Reachable.ValueBool() || state.Healthy.ValueBool() || state.Error.IsNull() {
+			t.Errorf("expected an unreachable service, got %v", state)
+		}
+		if !state.StatusCode.IsNull() || !state.ItemCount.IsNull() {
+			t.Errorf("expected null probe results, got %v", state)
+		}
+	})
+}
+
+func TestServiceDataSourceUnhealthyProvider(t *testing.T) {
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	// The provider is configured with a warning, so the data source can
+	// report the unhealthy service.
+	ctx := context.Background()
+	server, schemas := newTestProviderServer(t, unhealthy.URL, nil)
+	configType := schemas.DataSourceSchemas["inventory_service"].ValueType()
+
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{TypeName: "inventory_service", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	state, err := resp.State.Unmarshal(configType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values map[string]tftypes.Value
+	if err := state.As(&values); err != nil {
+		t.Fatal(err)
+	}
+	var reachable, healthy bool
+	if err := values["reachable"].As(&reachable); err != nil || !reachable {
+		t.Errorf("expected a reachable service, got %v", values["reachable"])
+	}
+	if err := values["healthy"].As(&healthy); err != nil || healthy {
+		t.Errorf("expected an unhealthy service, got %v", values["healthy"])
+	}
+}
@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &statsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &statsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &statsDataSource{}
+)
+
+// NewStatsDataSource is a helper function to simplify the provider implementation.
+func NewStatsDataSource() datasource.DataSource {
+	return &statsDataSource{}
+}
+
+// statsDataSource is the data source implementation.
+type statsDataSource struct {
+	client *client.Client
+	cipher *tagCipher
+}
+
+// statsDataSourceModel maps the data source schema data.
+type statsDataSourceModel struct {
+	Filter         *itemFilterModel           `tfsdk:"filter"`
+	TotalCount     types.Int64                `tfsdk:"total_count"`
+	TagCounts      map[string]int64           `tfsdk:"tag_counts"`
+	CurrencyCounts map[string]int64           `tfsdk:"currency_counts"`
+	Prices         map[string]priceStatsModel `tfsdk:"prices"`
+}
+
+// priceStatsModel maps the price aggregates of a single currency.
+type priceStatsModel struct {
+	Total   types.Number `tfsdk:"total"`
+	Min     types.Number `tfsdk:"min"`
+	Max     types.Number `tfsdk:"max"`
+	Average types.Number `tfsdk:"average"`
+}
+
+// priceStats accumulates the prices of a single currency.
+type priceStats struct {
+	count    int64
+	total    *big.Rat
+	min, max *big.Rat
+}
+
+// add adds a price to the aggregates.
+func (s *priceStats) add(amount *big.Rat) {
+	if s.count == 0 {
+		s.total = new(big.Rat)
+		s.min, s.max = amount, amount
+	}
+	s.count++
+	s.total.Add(s.total, amount)
+	if amount.Cmp(s.min) < 0 {
+		s.min = amount
+	}
+	if amount.Cmp(s.max) > 0 {
+		s.max = amount
+	}
+}
+
+// model returns the aggregates of a currency. The average is rounded to the
+// minor units of the currency.
+func (s *priceStats) model(currency string) priceStatsModel {
+	average := new(big.Rat).Quo(s.total, new(big.Rat).SetInt64(s.count))
+	return priceStatsModel{
+		Total:   types.NumberValue(ratBigFloat(s.total)),
+		Min:     types.NumberValue(ratBigFloat(s.min)),
+		Max:     types.NumberValue(ratBigFloat(s.max)),
+		Average: types.NumberValue(ratBigFloat(roundAmount(average, minorUnits(currency), roundingNearest))),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *statsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*inventoryProviderData)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+	d.client = data.client
+	d.cipher = data.cipher
+}
+
+// Metadata returns the data source type name.
+func (d *statsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stats"
+}
+
+// Schema defines the schema for the data source.
+func (d *statsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Count items and aggregate their prices. Prices are read from tags of the form CURRENCY:amount, such as USD:79,420.",
+		Attributes: map[string]schema.Attribute{
+			"filter": itemFilterAttribute(),
+			"total_count": schema.Int64Attribute{
+				Description: "The number of matching items.",
+				Computed:    true,
+			},
+			"tag_counts": schema.MapAttribute{
+				Description: "The number of matching items with each tag, not including labels or the owner ID. Items without a tag are not counted.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"currency_counts": schema.MapAttribute{
+				Description: "The number of matching items priced in each currency.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"prices": schema.MapNestedAttribute{
+				Description: "Aggregates of the prices of matching items, keyed by currency.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"total": schema.NumberAttribute{
+							Description: "The sum of the prices.",
+							Computed:    true,
+						},
+						"min": schema.NumberAttribute{
+							Description: "The lowest price.",
+							Computed:    true,
+						},
+						"max": schema.NumberAttribute{
+							Description: "The highest price.",
+							Computed:    true,
+						},
+						"average": schema.NumberAttribute{
+							Description: "The mean price, rounded to the minor units of the currency.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the data source configuration.
+func (d *statsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateItemFilter(ctx, req.Config, path.Root("filter"))...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *statsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read stats data source")
+	var state statsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := newItemFilter(ctx, state.Filter, path.Root("filter"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+	defer cancel()
+
+	items, err := findItems(ctx, d.client, client.FindItemsParams{})
+	if err != nil {
+		resp.Diagnostics.Append(clientErrorDiagnostic("Unable to List Items", "read", defaultReadTimeout, err))
+		return
+	}
+
+	var total int64
+	tagCounts := map[string]int64{}
+	currencyCounts := map[string]int64{}
+	prices := map[string]*priceStats{}
+	var undecryptable int
+
+	for _, item := range items {
+		storedTag, err := decodeItemTag(d.cipher, item)
+		if err != nil {
+			tflog.Debug(ctx, "Skipping item with undecryptable tag", map[string]any{"id": item.Id, "error": err.Error()})
+			undecryptable++
+			continue
+		}
+		if !filter.matches(item, storedTag) {
+			continue
+		}
+
+		total++
+		if storedTag.Tag == "" {
+			continue
+		}
+		tagCounts[storedTag.Tag]++
+
+		price, err := parsePriceTag(storedTag.Tag)
+		if err != nil {
+			continue
+		}
+		currencyCounts[price.Currency]++
+		if prices[price.Currency] == nil {
+			prices[price.Currency] = &priceStats{}
+		}
+		prices[price.Currency].add(price.Amount)
+	}
+
+	if undecryptable > 0 {
+		resp.Diagnostics.Append(undecryptableTagsWarning(undecryptable, "stats"))
+	}
+
+	state.TotalCount = types.Int64Value(total)
+	state.TagCounts = tagCounts
+	state.CurrencyCounts = currencyCounts
+	state.Prices = make(map[string]priceStatsModel, len(prices))
+	for currency, stats := range prices {
+		state.Prices[currency] = stats.model(currency)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading stats data source", map[string]any{"success": true, "count": total})
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestStatsDataSource(t *testing.T) {
+	c := newItemListClient(t, []client.Item{
+		{Id: 1, Name: "1965 Shelby Cobra", Tag: testTag("USD:79,420")},
+		{Id: 2, Name: "1953 Jaguar C-Type", Tag: testTag("USD:120,000.50|labels:env=prod")},
+		{Id: 3, Name: "1961 Jaguar E-Type", Tag: testTag("USD:79,420|labels:env=prod")},
+		{Id: 4, Name: "1953 Jaguar C-Type", Tag: testTag("GBP:61,000")},
+		{Id: 5, Name: "Floor Mats", Tag: testTag("Vintage")},
+		{Id: 6, Name: "Air Freshener"},
+	})
+
+	ctx := context.Background()
+	d := &statsDataSource{client: c}
+	filterType := itemFilterType()
+
+	read := func(t *testing.T, filter tftypes.Value) statsDataSourceModel {
+		t.Helper()
+		resp := readDataSource(t, d, map[string]tftypes.Value{"filter": filter})
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		var state statsDataSourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		return state
+	}
+
+	number := func(s string) *big.Float {
+		f, _ := new(big.Float).SetPrec(512).SetString(s)
+		return f
+	}
+
+	t.Run("all", func(t *testing.T) {
+		state := read(t, tftypes.NewValue(filterType, nil))
+
+		if state.TotalCount.ValueInt64() != 6 {
+			t.Errorf("expected 6 items, got %d", state.TotalCount.ValueInt64())
+		}
+		if state.TagCounts["USD:79,420"] != 2 || state.TagCounts["Vintage"] != 1 || len(state.TagCounts) != 4 {
+			t.Errorf("unexpected tag counts %v", state.TagCounts)
+		}
+		if state.CurrencyCounts["USD"] != 3 || state.CurrencyCounts["GBP"] != 1 || len(state.CurrencyCounts) != 2 {
+			t.Errorf("unexpected currency counts %v", state.CurrencyCounts)
+		}
+
+		usd := state.Prices["USD"]
+		for name, tt := range map[string]struct {
+			got  *big.Float
+			want string
+		}{
+			"total":   {usd.Total.ValueBigFloat(), "278840.5"},
+			"min":     {usd.Min.ValueBigFloat(), "79420"},
+			"max":     {usd.Max.ValueBigFloat(), "120000.5"},
+			"average": {usd.Average.ValueBigFloat(), "92946.83"},
+		} {
+			if tt.got.Cmp(number(tt.want)) != 0 {
+				t.Errorf("expected %s %s, got %s", name, tt.want, tt.got.Text('g', -1))
+			}
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		state := read(t, tftypes.NewValue(filterType, map[string]tftypes.Value{
+			"name_regex": tftypes.NewValue(tftypes.String, "Jaguar"),
+			"tag_regex":  tftypes.NewValue(tftypes.String, nil),
+			"labels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"env": tftypes.NewValue(tftypes.String, "prod"),
+			}),
+		}))
+
+		if state.TotalCount.ValueInt64() != 2 || state.CurrencyCounts["USD"] != 2 || len(state.Prices) != 1 {
+			t.Errorf("expected 2 USD items, got %v", state)
+		}
+	})
+	t.Run("invalid regex", func(t *testing.T) {
+		// A regular expression that was unknown during validation is only
+		// checked when reading.
+		resp := readDataSource(t, d, map[string]tftypes.Value{"filter": tftypes.NewValue(filterType, map[string]tftypes.Value{
+			"name_regex": tftypes.NewValue(tftypes.String, nil),
+			"tag_regex":  tftypes.NewValue(tftypes.String, "("),
+			"labels":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		})})
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Invalid Regular Expression" {
+			t.Errorf("expected an invalid regular expression error, got %v", resp.Diagnostics)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+// maxSuggestions bounds the number of items suggested when a lookup finds
+// nothing.
+const maxSuggestions = 5
+
+// suggestByName returns the items whose names are closest to name by edit
+// distance, ignoring case, closest first. Items that are too far away to be a
+// plausible typo are left out.
+func suggestByName(items []client.Item, name string) []client.Item {
+	target := strings.ToLower(name)
+	threshold := max(3, len([]rune(target))/2)
+
+	type candidate struct {
+		item     client.Item
+		distance int
+	}
+	var candidates []candidate
+	for _, item := range items {
+		d := editDistance(strings.ToLower(item.Name), target)
+		if d <= threshold {
+			candidates = append(candidates, candidate{item, d})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].item.Id < candidates[j].item.Id
+	})
+
+	suggestions := make([]client.Item, 0, min(len(candidates), maxSuggestions))
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		suggestions = append(suggestions, c.item)
+	}
+	return suggestions
+}
+
+// suggestByID returns the items whose identifiers are closest to id, closest
+// first.
+func suggestByID(items []client.Item, id int64) []client.Item {
+	distance := func(item client.Item) int64 {
+		if item.Id > id {
+			return item.Id - id
+		}
+		return id - item.Id
+	}
+
+	sorted := append([]client.Item(nil), items...)
+	sort.Slice(sorted, func(i, j int) bool {
+		di, dj := distance(sorted[i]), distance(sorted[j])
+		if di != dj {
+			return di < dj
+		}
+		return sorted[i].Id < sorted[j].Id
+	})
+
+	return sorted[:min(len(sorted), maxSuggestions)]
+}
+
+// formatSuggestions renders suggested items for a diagnostic, or nothing when
+// there are none.
+func formatSuggestions(items []client.Item) string {
+	if len(items) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = fmt.Sprintf("id=%d name=%q", item.Id, item.Name)
+	}
+	return "\n\nDid you mean:\n  " + strings.Join(lines, "\n  ")
+}
+
+// editDistance returns the Levenshtein distance between two strings, counted
+// in runes.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/superorbital/inventory-service/client"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"cobra", "cobra", 0},
+		{"cobra", "cobr", 1},
+		{"cobra", "kobra", 1},
+		{"cobra", "corba", 2},
+		{"", "cobra", 5},
+		{"café", "cafe", 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	items := []client.Item{
+		{Id: 10, Name: "1965 Shelby Cobra"},
+		{Id: 20, Name: "1953 Jaguar C-Type"},
+		{Id: 30, Name: "1961 Jaguar E-Type"},
+		{Id: 40, Name: "Floor Mats"},
+		{Id: 50, Name: "1965 shelby cobra"},
+	}
+
+	ids := func(items []client.Item) []int64 {
+		var ids []int64
+		for _, item := range items {
+			ids = append(ids, item.Id)
+		}
+		return ids
+	}
+	equal := func(a, b []int64) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	nameTests := []struct {
+		name string
+		want []int64
+	}{
+		{name: "1965 Shelby Cobar", want: []int64{10, 50}},
+		{name: "1953 Jaguar D-Type", want: []int64{20, 30}},
+		{name: "Floor Mat", want: []int64{40}},
+		{name: "Steering Wheel", want: nil},
+	}
+	for _, tt := range nameTests {
+		if got := ids(suggestByName(items, tt.name)); !equal(got, tt.want) {
+			t.Errorf("suggestByName(%q): expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	if got := ids(suggestByID(items, 26)); !equal(got, []int64{30, 20, 40, 10, 50}) {
+		t.Errorf("suggestByID: expected the closest IDs first, got %v", got)
+	}
+	if got := ids(suggestByID(append(items, client.Item{Id: 60}), 100)); len(got) != maxSuggestions {
+		t.Errorf("suggestByID: expected %d suggestions, got %v", maxSuggestions, got)
+	}
+
+	if got := formatSuggestions(nil); got != "" {
+		t.Errorf("expected no suggestions, got %q", got)
+	}
+	if got := formatSuggestions(items[:1]); got != "\n\nDid you mean:\n  id=10 name=\"1965 Shelby Cobra\"" {
+		t.Errorf("unexpected suggestions %q", got)
+	}
+}
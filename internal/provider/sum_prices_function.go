@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &sumPricesFunction{}
+
+// NewSumPricesFunction is a helper function to simplify the provider implementation.
+func NewSumPricesFunction() function.Function {
+	return &sumPricesFunction{}
+}
+
+// sumPricesFunction is the function implementation.
+type sumPricesFunction struct{}
+
+// Metadata returns the function name.
+func (f *sumPricesFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sum_prices"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *sumPricesFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Sum price tags",
+		Description: "Add up tags of the form CURRENCY:amount, such as USD:79,420, using exact decimal arithmetic. " +
+			"The total is in the currency of the first tag and is rounded to its minor units. Tags in other currencies are rejected, " +
+			"unless exchange rates are given to convert them, such as { base = \"USD\", rates = { EUR = 0.92 } }.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "tags",
+				Description: "The price tags to add up.",
+				ElementType: types.StringType,
+			},
+		},
+		VariadicParameter: function.ObjectParameter{
+			Name:           "rates",
+			Description:    "The exchange rates used to convert tags to the currency of the first tag.",
+			AttributeTypes: exchangeRatesAttributeTypes,
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run sums the prices.
+func (f *sumPricesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tags []string
+	var arguments []exchangeRatesArgument
+
+	resp.Error = req.Arguments.Get(ctx, &tags, &arguments)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(tags) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "expected at least one tag")
+		return
+	}
+
+	var rates *exchangeRates
+	switch len(arguments) {
+	case 0:
+	case 1:
+		rates, resp.Error = functionExchangeRates(1, arguments[0])
+		if resp.Error != nil {
+			return
+		}
+	default:
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("expected at most one set of rates, got %d", len(arguments)))
+		return
+	}
+
+	var currency string
+	total := new(big.Rat)
+	for i, tag := range tags {
+		price, err := parsePriceTag(tag)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("element %d: %s", i, err))
+			return
+		}
+		if i == 0 {
+			currency = price.Currency
+		}
+
+		amount := price.Amount
+		if price.Currency != currency {
+			if rates == nil {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(
+					"element %d: tag %q is not in %s, the currency of the first tag; give exchange rates to convert it", i, tag, currency))
+				return
+			}
+			amount, err = rates.convert(price.Amount, price.Currency, currency)
+			if err != nil {
+				resp.Error = function.NewArgumentFuncError(1, err.Error())
+				return
+			}
+		}
+		total.Add(total, amount)
+	}
+
+	resp.Error = resp.Result.Set(ctx, canonicalPriceTag(currency, total, roundingNearest, canonicalPriceFormat).String())
+}
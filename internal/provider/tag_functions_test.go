@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction calls a function with the given arguments. The variadic
+// arguments are passed as the final tuple.
+func runFunction(t *testing.T, f function.Function, args []attr.Value, variadic ...string) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	values := make([]attr.Value, len(variadic))
+	for i, v := range variadic {
+		values[i] = types.StringValue(v)
+	}
+	return runFunctionValues(t, f, args, values...)
+}
+
+// runFunctionValues calls a function with the given arguments, passing
+// variadic arguments of any type as the final tuple of variadic functions.
+func runFunctionValues(t *testing.T, f function.Function, args []attr.Value, variadic ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+
+	if definition.Definition.VariadicParameter != nil {
+		variadicTypes := make([]attr.Type, len(variadic))
+		for i, v := range variadic {
+			variadicTypes[i] = v.Type(ctx)
+		}
+		args = append(args, types.TupleValueMust(variadicTypes, variadic))
+	}
+
+	result, funcErr := definition.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr)
+	}
+
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestParseTagFunction(t *testing.T) {
+	tests := []struct {
+		tag       string
+		locale    []string
+		currency  string
+		amount    string
+		remainder string
+		wantErr   bool
+	}{
+		{tag: "USD:79,420", currency: "USD", amount: "79420"},
+		{tag: "USD:2.99", currency: "USD", amount: "2.99"},
+		{tag: "EUR:1.234,5 net", locale: []string{"de"}, currency: "EUR", amount: "1234.5", remainder: " net"},
+		{tag: "CHF:1'234.50", locale: []string{"de-CH"}, currency: "CHF", amount: "1234.5"},
+		{tag: "EUR:1 234,50", locale: []string{"fr_FR"}, currency: "EUR", amount: "1234.5"},
+		{tag: "Vintage", wantErr: true},
+		{tag: "USD:1", locale: []string{"tlh"}, wantErr: true},
+		{tag: "USD:1", locale: []string{"en", "de"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		value, funcErr := runFunction(t, NewParseTagFunction(), []attr.Value{types.StringValue(tt.tag)}, tt.locale...)
+		if tt.wantErr {
+			if funcErr == nil {
+				t.Errorf("%q: expected an error", tt.tag)
+			}
+			continue
+		}
+		if funcErr != nil {
+			t.Errorf("%q: unexpected error: %s", tt.tag, funcErr)
+			continue
+		}
+
+		object, ok := value.(types.Object)
+		if !ok {
+			t.Fatalf("%q: expected an object, got %T", tt.tag, value)
+		}
+		attributes := object.Attributes()
+		amount, _ := new(big.Float).SetPrec(512).SetString(tt.amount)
+		if want := types.StringValue(tt.currency); !attributes["currency"].Equal(want) {
+			t.Errorf("%q: expected currency %s, got %s", tt.tag, want, attributes["currency"])
+		}
+		if got, ok := attributes["amount"].(types.Number); !ok || got.ValueBigFloat().Cmp(amount) != 0 {
+			t.Errorf("%q: expected amount %s, got %s", tt.tag, tt.amount, attributes["amount"])
+		}
+		if want := types.StringValue(tt.remainder); !attributes["remainder"].Equal(want) {
+			t.Errorf("%q: expected remainder %s, got %s", tt.tag, want, attributes["remainder"])
+		}
+	}
+}
+
+func TestFormatTagFunction(t *testing.T) {
+	tests := []struct {
+		currency string
+		amount   string
+		locale   []string
+		want     string
+		wantErr  bool
+	}{
+		{currency: "USD", amount: "79420", want: "USD:79,420"},
+		{currency: "USD", amount: "2.99", want: "USD:2.99"},
+		{currency: "USD", amount: "1234.5", want: "USD:1,234.50"},
+		{currency: "USD", amount: "2.999", want: "USD:3"},
+		{currency: "JPY", amount: "1234.5", want: "JPY:1,235"},
+		{currency: "KWD", amount: "0.1234", want: "KWD:0.123"},
+		{currency: "EUR", amount: "1234567.8", locale: []string{"de"}, want: "EUR:1.234.567,80"},
+		{currency: "CHF", amount: "-1234.5", locale: []string{"de-CH"}, want: "CHF:-1'234.50"},
+		{currency: "usd", amount: "1", wantErr: true},
+		{currency: "USD", amount: "1", locale: []string{"tlh"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		amount, _ := new(big.Float).SetPrec(512).SetString(tt.amount)
+		value, funcErr := runFunction(t, NewFormatTagFunction(), []attr.Value{types.StringValue(tt.currency), types.NumberValue(amount)}, tt.locale...)
+		if tt.wantErr {
+			if funcErr == nil {
+				t.Errorf("%s %s: expected an error", tt.currency, tt.amount)
+			}
+			continue
+		}
+		if funcErr != nil {
+			t.Errorf("%s %s: unexpected error: %s", tt.currency, tt.amount, funcErr)
+			continue
+		}
+
+		if want := types.StringValue(tt.want); !value.Equal(want) {
+			t.Errorf("%s %s: expected %s, got %s", tt.currency, tt.amount, want, value)
+		}
+	}
+}
+
+func TestFormatTagFunctionRoundTrip(t *testing.T) {
+	tests := []struct {
+		currency string
+		amount   string
+		locale   []string
+	}{
+		{currency: "USD", amount: "79420"},
+		{currency: "KWD", amount: "1.5"},
+		{currency: "EUR", amount: "79420", locale: []string{"de"}},
+		{currency: "EUR", amount: "1234567.8", locale: []string{"de"}},
+		{currency: "EUR", amount: "-1234.5", locale: []string{"fr"}},
+		{currency: "CHF", amount: "1234.5", locale: []string{"de-CH"}},
+		{currency: "JPY", amount: "1234", locale: []string{"ja"}},
+	}
+
+	for _, tt := range tests {
+		amount, _ := new(big.Float).SetPrec(512).SetString(tt.amount)
+		value, funcErr := runFunction(t, NewFormatTagFunction(), []attr.Value{types.StringValue(tt.currency), types.NumberValue(amount)}, tt.locale...)
+		if funcErr != nil {
+			t.Errorf("%s %s %v: unexpected error: %s", tt.currency, tt.amount, tt.locale, funcErr)
+			continue
+		}
+
+		parsed, funcErr := runFunction(t, NewParseTagFunction(), []attr.Value{value}, tt.locale...)
+		if funcErr != nil {
+			t.Errorf("%s %s %v: unexpected error parsing %s: %s", tt.currency, tt.amount, tt.locale, value, funcErr)
+			continue
+		}
+
+		want := types.ObjectValueMust(priceAttributeTypes, map[string]attr.Value{
+			"currency":  types.StringValue(tt.currency),
+			"amount":    types.NumberValue(amount),
+			"remainder": types.StringValue(""),
+		})
+		if !parsed.Equal(want) {
+			t.Errorf("%s %s %v: %s parsed as %s", tt.currency, tt.amount, tt.locale, value, parsed)
+		}
+	}
+}
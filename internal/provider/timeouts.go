@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Default deadlines for calls to the inventory service when no timeouts block
+// is configured.
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
+// clientErrorDiagnostic returns the diagnostic for an error returned by the
+// inventory client. Errors caused by the operation deadline get their own
+// summary so they can be told apart from other transport errors.
+func clientErrorDiagnostic(summary string, operation string, timeout time.Duration, err error) diag.Diagnostic {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return diag.NewErrorDiagnostic(
+			"Inventory Service Timed Out",
+			fmt.Sprintf("The %s operation did not complete within its timeout of %s. "+
+				"The %s timeout can be raised in the timeouts block.\n\n"+
+				"Inventory Client Error: %s", operation, timeout, operation, err),
+		)
+	}
+
+	return diag.NewErrorDiagnostic(summary, err.Error())
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/superorbital/inventory-service/client"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Defaults for the consistency block.
+const (
+	defaultConsistencyPollInterval         = time.Second
+	defaultConsistencyConsecutiveSuccesses = 1
+)
+
+// consistencyWaiter polls the inventory service after a write until the change
+// is visible to reads. A nil consistencyWaiter does not wait.
+type consistencyWaiter struct {
+	pollInterval         time.Duration
+	consecutiveSuccesses int
+}
+
+// waiterCheck reports whether the awaited state has been observed, along with
+// a short description of what was observed for the logs.
+type waiterCheck func(ctx context.Context) (bool, string, error)
+
+// transientError marks a failed request that may succeed when it is retried,
+// such as a server error or a dropped connection.
+type transientError struct {
+	err error
+}
+
+func (e transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e transientError) Unwrap() error {
+	return e.err
+}
+
+// isTransientError reports whether err is worth retrying.
+func isTransientError(err error) bool {
+	var transient transientError
+	return errors.As(err, &transient)
+}
+
+// requestError marks a request that failed on the network as transient,
+// unless ctx is done.
+func requestError(ctx context.Context, err error) error {
+	var netErr net.Error
+	if ctx.Err() == nil && errors.As(err, &netErr) {
+		return transientError{err}
+	}
+	return err
+}
+
+// statusError marks the error for an unexpected HTTP status code as transient
+// when the service is throttling or failing.
+func statusError(statusCode int, err error) error {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return transientError{err}
+	default:
+		return err
+	}
+}
+
+// wait polls check until it succeeds the configured number of times in a row.
+// Transient errors are retried like a stale read. The wait is bounded by the
+// deadline of ctx, and any other error ends it.
+func (w *consistencyWaiter) wait(ctx context.Context, description string, check waiterCheck) error {
+	if w == nil {
+		return nil
+	}
+
+	start := time.Now()
+	successes := 0
+
+	for attempt := 1; ; attempt++ {
+		done, observed, err := check(ctx)
+		if err != nil {
+			if ctx.Err() == nil && !isTransientError(err) {
+				return err
+			}
+			done, observed = false, err.Error()
+		}
+
+		if done {
+			successes++
+		} else {
+			successes = 0
+		}
+
+		tflog.Info(ctx, "Waiting for "+description, map[string]any{
+			"attempt":   attempt,
+			"observed":  observed,
+			"successes": successes,
+			"required":  w.consecutiveSuccesses,
+			"elapsed":   time.Since(start).String(),
+		})
+
+		if successes >= w.consecutiveSuccesses {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gave up waiting for %s after %d attempts, last observed %s: %w", description, attempt, observed, ctx.Err())
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// itemExistsCheck waits until the item can be read.
+func itemExistsCheck(c *client.Client, id int64) waiterCheck {
+	return func(ctx context.Context) (bool, string, error) {
+		item, status, err := findItemForWaiter(ctx, c, id)
+		if err != nil {
+			return false, "", err
+		}
+		return item != nil, status, nil
+	}
+}
+
+// itemMatchesCheck waits until the item reads back with the expected name and
+// stored tag.
+func itemMatchesCheck(c *client.Client, id int64, expected client.NewItem) waiterCheck {
+	return func(ctx context.Context) (bool, string, error) {
+		item, status, err := findItemForWaiter(ctx, c, id)
+		if err != nil || item == nil {
+			return false, status, err
+		}
+
+		if item.Name != expected.Name || item.Tag == nil || expected.Tag == nil || *item.Tag != *expected.Tag {
+			return false, "stale item", nil
+		}
+		return true, status, nil
+	}
+}
+
+// itemGoneCheck waits until reading the item returns a 404.
+func itemGoneCheck(c *client.Client, id int64) waiterCheck {
+	return func(ctx context.Context) (bool, string, error) {
+		item, status, err := findItemForWaiter(ctx, c, id)
+		if err != nil {
+			return false, "", err
+		}
+		return item == nil, status, nil
+	}
+}
+
+// findItemForWaiter reads an item, returning nil when the service reports it
+// does not exist.
+func findItemForWaiter(ctx context.Context, c *client.Client, id int64) (*client.Item, string, error) {
+	itemResponse, err := c.FindItemById(ctx, id)
+	if err != nil {
+		return nil, "", requestError(ctx, err)
+	}
+	defer itemResponse.Body.Close()
+
+	switch itemResponse.StatusCode {
+	case http.StatusNotFound:
+		return nil, itemResponse.Status, nil
+	case http.StatusOK:
+		var item client.Item
+		if err := json.NewDecoder(itemResponse.Body).Decode(&item); err != nil {
+			return nil, "", fmt.Errorf("invalid format received for Item: %w", err)
+		}
+		return &item, itemResponse.Status, nil
+	default:
+		return nil, "", statusError(itemResponse.StatusCode, fmt.Errorf("unexpected HTTP error code received for Item: %s", itemResponse.Status))
+	}
+}
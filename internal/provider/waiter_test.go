@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConsistencyWaiter(t *testing.T) {
+	waiter := &consistencyWaiter{
+		pollInterval:         time.Millisecond,
+		consecutiveSuccesses: 2,
+	}
+
+	// A stale read in between resets the count of consecutive successes.
+	observations := []bool{false, true, false, true, true, false}
+	attempts := 0
+	err := waiter.wait(context.Background(), "test", func(context.Context) (bool, string, error) {
+		done := observations[attempts]
+		attempts++
+		return done, "", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attempts != 5 {
+		t.Errorf("expected 5 attempts, got %d", attempts)
+	}
+
+	// Waiting is bounded by the context deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = waiter.wait(ctx, "test", func(context.Context) (bool, string, error) {
+		return false, "404 Not Found", nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline error, got %v", err)
+	}
+
+	// Transient errors are retried, other errors end the wait.
+	attempts = 0
+	err = waiter.wait(context.Background(), "test", func(context.Context) (bool, string, error) {
+		attempts++
+		if attempts == 1 {
+			return false, "", transientError{errors.New("502 Bad Gateway")}
+		}
+		return true, "200 OK", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+
+	permanent := errors.New("403 Forbidden")
+	err = waiter.wait(context.Background(), "test", func(context.Context) (bool, string, error) {
+		return false, "", permanent
+	})
+	if !errors.Is(err, permanent) {
+		t.Errorf("expected the check error, got %v", err)
+	}
+
+	// A nil waiter does not call the check at all.
+	var disabled *consistencyWaiter
+	err = disabled.wait(context.Background(), "test", func(context.Context) (bool, string, error) {
+		t.Error("unexpected check")
+		return false, "", nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}